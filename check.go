@@ -0,0 +1,95 @@
+package gofigure
+
+import (
+	"errors"
+	"reflect"
+)
+
+// AddCheckConfig will add a "check-config" flag to the set of options. When
+// the flag is given ParseUsing will check the external configuration file at
+// the provided path or URI using CheckConfig, returning the result, rather than
+// configuring the program. The path is available in Check after parsing, and
+// the program should exit once the result has been reported. No short flag is
+// added as it would clash with the config file flag.
+func (c *Configuration) AddCheckConfig() {
+	g := c.Group(internalGroup)
+	g.Add(Optional("Check Config", "check-config", &c.Check, "", Flag, HideValue,
+		"Check an external JSON configuration file and exit"))
+}
+
+// CheckConfig will load the external configuration file at the given path or
+// URI and check it against the Settings with a Key on the Configuration and
+// all Commands, using the same rules as Schema. All problems found are
+// reported, including unknown keys, invalid values, values that fail
+// validation, and missing values for required Settings that can only be set
+// using a Key. The returned error can be given to Format. Checking does not
+// change any Values.
+func (c *Configuration) CheckConfig(uri string) error {
+	var (
+		settings Settings
+		errs     []error
+	)
+
+	seen := map[string]bool{}
+
+	for _, ref := range c.references(nil) {
+		for _, group := range ref.groups {
+			for _, setting := range group.Settings {
+				if name, ok := setting.key(); ok && !seen[name] {
+					seen[name] = true
+					settings = append(settings, setting.clone())
+				}
+			}
+		}
+	}
+
+	data, err := Load(uri)
+
+	if err != nil {
+		return err
+	}
+
+	if data, err = c.seal(data); err != nil {
+		return err
+	} else if data, err = settings.interpolate(data, c.Interpolate); err != nil {
+		return err
+	}
+
+	for _, parameter := range data.sorted() {
+		option := Options{parameter: data[parameter]}
+
+		if err = settings.assign(option); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, setting := range settings {
+		if setting.keyRequired() && setting.Value.Source == None {
+			errs = append(errs, setting.missing(c.Prefix))
+		} else if setting.Value.Source == Key {
+			if err = setting.validate(c.Prefix); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// clone the Setting, giving it a new Value with a Ptr to a new variable. The
+// cloned Value is reset to its default state.
+func (s Setting) clone() *Setting {
+	v := *s.Value
+	v.Ptr = reflect.New(reflect.TypeOf(s.Value.Ptr).Elem()).Interface()
+	v.Source = None
+	v.Provenance = ""
+
+	if v.base != nil {
+		reflect.ValueOf(v.Ptr).Elem().Set(reflect.ValueOf(v.base))
+		v.Source = Default
+	}
+
+	s.Value = &v
+
+	return &s
+}
@@ -0,0 +1,103 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleConfiguration_CheckConfig() {
+	var (
+		name    string
+		port    int
+		timeout string
+		token   string
+	)
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	config.AddCheckConfig()
+
+	group := config.Group("settings")
+	group.Add(gofigure.Optional("Name", "name", &name, "example",
+		gofigure.NamedSources, gofigure.ReportValue, "Application name"))
+	group.Add(gofigure.Optional("Port", "port", &port, 80,
+		gofigure.NamedSources, gofigure.ReportValue, "Port to listen on"))
+	group.Add(gofigure.Optional("Timeout", "timeout", &timeout, "1s",
+		gofigure.NamedSources, gofigure.ReportValue, "Request timeout"))
+	group.Add(gofigure.Required("Token", "token", &token,
+		gofigure.Key, gofigure.MaskValue, "Access token"))
+	group.Add(gofigure.Required("User", "user", new(string),
+		gofigure.NamedSources, gofigure.ReportValue, "User name"))
+
+	err := config.ParseUsing([]string{"--check-config", "testdata/invalid.json"})
+
+	fmt.Println(config.Check)
+	fmt.Println(config.Format(err))
+	fmt.Println(name, port, timeout)
+
+	// Output:
+	// testdata/invalid.json
+	// invalid value '1': [JSON key: "name"]
+	// invalid value 'http': [JSON key: "port"]
+	// unexpected argument: [JSON key: "timout"] (did you mean JSON key: "timeout"?)
+	// missing required option: [JSON key: "token"]
+	// example 80 1s
+}
+
+func TestConfiguration_CheckConfig(t *testing.T) {
+	t.Run("Valid files pass", func(t *testing.T) {
+		t.Parallel()
+
+		var name, address string
+
+		config := gofigure.NewConfiguration("")
+		group := config.Group("settings")
+		group.Add(gofigure.Required("Name", "name", &name, gofigure.Key,
+			gofigure.ReportValue, "name"))
+		group.Add(gofigure.Optional("Address", "address", &address, "",
+			gofigure.Key, gofigure.ReportValue, "address"))
+		group.Add(gofigure.Optional("Duration", "duration", new(string), "",
+			gofigure.Key, gofigure.ReportValue, "duration"))
+		group.Add(gofigure.Optional("Int", "int", new(int), 0,
+			gofigure.Key, gofigure.ReportValue, "int"))
+		group.Add(gofigure.Optional("Float", "float", new(float64), 0,
+			gofigure.Key, gofigure.ReportValue, "float"))
+
+		assert.NoError(t, config.CheckConfig("testdata/config.json"))
+		assert.Empty(t, name)
+	})
+
+	t.Run("Keys for all Commands are checked", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Group("settings").Add(gofigure.Optional("Name", "name", new(string),
+			"", gofigure.Key, gofigure.ReportValue, "name"))
+		serve := config.Command("serve", "serve").Group("server")
+		serve.Add(gofigure.Optional("Address", "address", new(string), "",
+			gofigure.Key, gofigure.ReportValue, "address"))
+		serve.Add(gofigure.Optional("Duration", "duration", new(string), "",
+			gofigure.Key, gofigure.ReportValue, "duration"))
+		serve.Add(gofigure.Optional("Int", "int", new(int), 0,
+			gofigure.Key, gofigure.ReportValue, "int"))
+		serve.Add(gofigure.Optional("Float", "float", new(float64), 0,
+			gofigure.Key, gofigure.ReportValue, "float"))
+		serve.Add(gofigure.Required("Token", "token", new(string),
+			gofigure.Key|gofigure.EnvVar, gofigure.MaskValue, "token"))
+
+		assert.NoError(t, config.CheckConfig("testdata/config.json"))
+	})
+
+	t.Run("Files that can't be loaded are reported", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		err := config.CheckConfig("/does/not/exist")
+
+		assert.ErrorIs(t, err, gofigure.ErrLoadingJSON)
+		assert.Equal(t, "error loading config: [file: /does/not/exist]",
+			config.Format(err))
+	})
+}
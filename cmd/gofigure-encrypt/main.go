@@ -0,0 +1,74 @@
+// Command gofigure-encrypt creates keys and encrypts values for use in
+// gofigure configuration files.
+//
+//	gofigure-encrypt keygen > key
+//	GOFIGURE_ENCRYPTION_KEY_FILE=key gofigure-encrypt encrypt < password
+//
+// The value to encrypt is read from standard input so it doesn't end up in the
+// shell history. The encrypted value is written to standard output.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/domdavis/gofigure"
+)
+
+func main() {
+	config := gofigure.NewConfiguration("GOFIGURE")
+	config.AddHelp(gofigure.ShortFlag)
+	config.AddEncryptionKey(gofigure.EnvVar)
+
+	config.Command("keygen", "Generate a new encryption key").Handle(keygen)
+	config.Command("encrypt", "Encrypt a value read from standard input using "+
+		"the encryption key").Handle(encrypt)
+
+	if err := config.Parse(); err != nil {
+		fmt.Fprintln(os.Stderr, config.Format(err))
+		os.Exit(1)
+	}
+
+	if config.Help || config.Selected == nil {
+		fmt.Println(config.Usage())
+
+		return
+	}
+
+	if err := config.Dispatch(); err != nil {
+		fmt.Fprintln(os.Stderr, config.Format(err))
+		os.Exit(1)
+	}
+}
+
+func keygen(*gofigure.Configuration) error {
+	key, err := gofigure.GenerateKey()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(key.Reveal())
+
+	return nil
+}
+
+func encrypt(config *gofigure.Configuration) error {
+	b, err := io.ReadAll(os.Stdin)
+
+	if err != nil {
+		return fmt.Errorf("failed to read value: %w", err)
+	}
+
+	value, err := gofigure.Encrypt(config.EncryptionKey, strings.TrimRight(string(b), "\r\n"))
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(value)
+
+	return nil
+}
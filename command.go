@@ -0,0 +1,119 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Command in a Configuration. Commands form a tree below the Configuration
+// with each Command having its own Groups of Settings. A Command inherits the
+// Settings of the Configuration and of any parent Commands.
+type Command struct {
+	Name        string
+	Description string
+	Groups      []*Group
+	Commands    []*Command
+	Handler     Handler
+
+	config   *Configuration
+	parent   *Command
+	groups   map[string]*Group
+	commands map[string]*Command
+}
+
+// Handler for a Command. The Handler is called by Configuration.Dispatch with
+// the parsed Configuration.
+type Handler func(config *Configuration) error
+
+// ErrNoHandler is returned by Dispatch if the selected Command has no Handler.
+var ErrNoHandler = errors.New("no handler for command")
+
+// Group of Settings for this Command.
+func (c *Command) Group(name string) *Group {
+	return group(&c.groups, &c.Groups, name)
+}
+
+// Command returns the named sub Command of this Command, creating it if it
+// doesn't exist.
+func (c *Command) Command(name, description string) *Command {
+	return command(&c.commands, &c.Commands, c.config, c, name, description)
+}
+
+// Handle sets the Handler for the Command, returning the Command.
+func (c *Command) Handle(handler Handler) *Command {
+	c.Handler = handler
+
+	return c
+}
+
+// Path to this Command from the root of the Configuration.
+func (c *Command) Path() []string {
+	if c == nil {
+		return nil
+	}
+
+	return append(c.parent.Path(), c.Name)
+}
+
+// Usage string for this Command, including any inherited Settings.
+func (c *Command) Usage() string {
+	return c.config.usage(c)
+}
+
+func (c *Command) String() string {
+	return strings.Join(c.Path(), " ")
+}
+
+// lineage returns the Commands from the root of the Configuration down to,
+// and including, this Command.
+func (c *Command) lineage() []*Command {
+	if c == nil {
+		return nil
+	}
+
+	return append(c.parent.lineage(), c)
+}
+
+func command(index *map[string]*Command, commands *[]*Command,
+	config *Configuration, parent *Command, name, description string) *Command {
+	if name == "" || strings.HasPrefix(name, "-") {
+		panic(fmt.Sprintf("invalid command name: %q", name))
+	}
+
+	if *index == nil {
+		*index = map[string]*Command{}
+	}
+
+	cmd, ok := (*index)[name]
+
+	if !ok {
+		cmd = &Command{
+			Name:        name,
+			Description: description,
+			config:      config,
+			parent:      parent,
+		}
+
+		(*index)[name] = cmd
+		*commands = append(*commands, cmd)
+	}
+
+	return cmd
+}
+
+func group(index *map[string]*Group, groups *[]*Group, name string) *Group {
+	if *index == nil {
+		*index = map[string]*Group{}
+	}
+
+	g, ok := (*index)[name]
+
+	if !ok {
+		g = &Group{Name: name}
+		(*index)[name] = g
+		*groups = append(*groups, g)
+	}
+
+	return g
+}
@@ -0,0 +1,306 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleCommand() {
+	var (
+		verbose bool
+		port    int
+		steps   int
+	)
+
+	config := gofigure.NewConfiguration("TOOL")
+	config.Group("global").Add(gofigure.Optional("Verbose", "verbose",
+		&verbose, false, gofigure.Flag, gofigure.ReportValue, "Verbose output"))
+
+	config.Command("serve", "Run the server").Handle(
+		func(config *gofigure.Configuration) error {
+			fmt.Printf("serving on %d (verbose: %t)\n", port, verbose)
+
+			return nil
+		}).Group("server").Add(gofigure.Optional("Port", "port", &port, 80,
+		gofigure.Flag, gofigure.ReportValue, "Port to listen on"))
+
+	up := config.Command("migrate", "Run migrations").Command("up",
+		"Migrate up").Handle(func(config *gofigure.Configuration) error {
+		fmt.Printf("migrating %d steps\n", steps)
+
+		return nil
+	})
+
+	up.Group("migration").Add(gofigure.Required("Steps", "steps", &steps,
+		gofigure.Flag, gofigure.ReportValue, "Steps to migrate"))
+
+	if err := config.ParseUsing([]string{"serve", "--port", "8080",
+		"--verbose"}); err != nil {
+		fmt.Println(config.Format(err))
+	} else if err = config.Dispatch(); err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	if err := config.ParseUsing([]string{"migrate", "up", "--steps", "2"}); err != nil {
+		fmt.Println(config.Format(err))
+	} else if err = config.Dispatch(); err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	fmt.Println(up.Usage())
+
+	// Output:
+	// serving on 8080 (verbose: true)
+	// migrating 2 steps
+	// usage: migrate up
+	//   Verbose [--verbose]
+	//     Verbose output (default: false)
+	//
+	//   Steps [--steps]
+	//     Steps to migrate (required)
+}
+
+func TestCommand_Path(t *testing.T) {
+	t.Run("A nil Command has no Path", func(t *testing.T) {
+		t.Parallel()
+
+		var cmd *gofigure.Command
+
+		assert.Empty(t, cmd.Path())
+	})
+
+	t.Run("Nested Commands include their parents", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		cmd := config.Command("a", "a").Command("b", "b").Command("c", "c")
+
+		assert.Equal(t, []string{"a", "b", "c"}, cmd.Path())
+		assert.Equal(t, "a b c", cmd.String())
+	})
+}
+
+func TestCommand_Command(t *testing.T) {
+	t.Run("Commands are only created once", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		a := config.Command("a", "a")
+
+		assert.Same(t, a, config.Command("a", "a"))
+		assert.Same(t, a.Command("b", "b"), a.Command("b", "b"))
+		assert.Len(t, config.Commands, 1)
+		assert.Len(t, a.Commands, 1)
+	})
+
+	t.Run("Commands must have a valid name", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+
+		assert.Panics(t, func() { config.Command("", "empty") })
+		assert.Panics(t, func() { config.Command("-a", "flag") })
+	})
+}
+
+func TestConfiguration_Dispatch(t *testing.T) {
+	t.Run("The Configuration Handler is used with no Command", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		config := gofigure.NewConfiguration("")
+		config.Command("cmd", "command")
+		config.Handler = func(*gofigure.Configuration) error {
+			called = true
+
+			return nil
+		}
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.NoError(t, config.Dispatch())
+		assert.True(t, called)
+	})
+
+	t.Run("A Command without a Handler will error", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Command("cmd", "command")
+
+		assert.NoError(t, config.ParseUsing([]string{"cmd"}))
+
+		err := config.Dispatch()
+
+		assert.ErrorIs(t, err, gofigure.ErrNoHandler)
+		assert.Equal(t, "no handler for command: [cmd]", config.Format(err))
+	})
+
+	t.Run("A Configuration without a Handler will error", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Command("cmd", "command")
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+
+		err := config.Dispatch()
+
+		assert.ErrorIs(t, err, gofigure.ErrNoHandler)
+		assert.Equal(t, "no handler for command", config.Format(err))
+	})
+
+	t.Run("Handler errors are returned", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Command("cmd", "command").Handle(
+			func(*gofigure.Configuration) error { return assert.AnError })
+
+		assert.NoError(t, config.ParseUsing([]string{"cmd"}))
+		assert.ErrorIs(t, config.Dispatch(), assert.AnError)
+	})
+
+	t.Run("Handlers are not called when completing", func(t *testing.T) {
+		t.Parallel()
+
+		var called bool
+
+		config := gofigure.NewConfiguration("")
+		config.Handler = func(*gofigure.Configuration) error {
+			called = true
+
+			return nil
+		}
+
+		err := config.ParseUsing([]string{gofigure.CompleteCommand, "--"})
+
+		assert.ErrorIs(t, err, gofigure.ErrCompleting)
+		assert.ErrorIs(t, config.Dispatch(), gofigure.ErrCompleting)
+		assert.False(t, called)
+	})
+}
+
+func TestConfiguration_ParseUsing(t *testing.T) {
+	t.Run("Settings on unselected Commands are not used", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b string
+
+		config := gofigure.NewConfiguration("")
+		config.Command("a", "a").Group("a").Add(gofigure.Required("A", "a", &a,
+			gofigure.Flag, gofigure.ReportValue, "a"))
+		config.Command("b", "b").Group("b").Add(gofigure.Required("B", "b", &b,
+			gofigure.Flag, gofigure.ReportValue, "b"))
+
+		assert.NoError(t, config.ParseUsing([]string{"a", "--a", "set"}))
+		assert.Equal(t, "set", a)
+
+		err := config.ParseUsing([]string{"b", "--a", "set"})
+
+		assert.Equal(t, "unexpected argument: [--a]", config.Format(err))
+	})
+
+	t.Run("Unknown commands are reported", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Command("a", "a")
+
+		err := config.ParseUsing([]string{"b"})
+
+		assert.Equal(t, "unexpected argument: [b]", config.Format(err))
+	})
+
+	//nolint:paralleltest // Testing environment variables.
+	t.Run("Environment variables can include the Command", func(t *testing.T) {
+		var value string
+
+		t.Setenv("GOFIGURE_TEST_MIGRATE_UP_VALUE", "command")
+
+		config := gofigure.NewConfiguration("GOFIGURE_TEST")
+		config.CommandEnv = true
+		config.Command("migrate", "migrate").Command("up", "up").Group(
+			"test").Add(gofigure.Required("Value", "value", &value,
+			gofigure.EnvVar, gofigure.ReportValue, "test value"))
+
+		assert.NoError(t, config.ParseUsing([]string{"migrate", "up"}))
+		assert.Equal(t, "command", value)
+	})
+
+	//nolint:paralleltest // Testing environment variables.
+	t.Run("Command environment variables are reported", func(t *testing.T) {
+		var value int
+
+		t.Setenv("GOFIGURE_TEST_RUN_VALUE", "string")
+
+		config := gofigure.NewConfiguration("GOFIGURE_TEST")
+		config.CommandEnv = true
+		config.Command("run", "run").Group("test").Add(gofigure.Required(
+			"Value", "value", &value, gofigure.EnvVar, gofigure.ReportValue,
+			"test value"))
+
+		err := config.ParseUsing([]string{"run"})
+
+		assert.Equal(t, "invalid value 'string': [env GOFIGURE_TEST_RUN_VALUE]",
+			config.Format(err))
+	})
+}
+
+func TestCommand_Usage(t *testing.T) {
+	t.Run("Sub commands are listed", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Command("serve", "Run the server")
+
+		assert.Equal(t, "usage:\ncommands:\n  serve\n    Run the server\n\n",
+			config.Usage())
+	})
+
+	t.Run("Selected commands are used by the Configuration", func(t *testing.T) {
+		t.Parallel()
+
+		var value string
+
+		config := gofigure.NewConfiguration("TOOL")
+		config.CommandEnv = true
+		config.Command("run", "run").Group("test").Add(gofigure.Required(
+			"Value", "value", &value, gofigure.EnvVar, gofigure.ReportValue,
+			"test value"))
+
+		_ = config.ParseUsing([]string{"run"})
+
+		assert.Equal(t, "usage: run\n  Value [env TOOL_RUN_VALUE]\n"+
+			"    test value (required)\n\n", config.Usage())
+	})
+}
+
+func TestConfiguration_Report_Command(t *testing.T) {
+	t.Run("The selected Command is included in the Report", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			verbose bool
+			port    int
+		)
+
+		config := gofigure.NewConfiguration("")
+		config.Group("global").Add(gofigure.Optional("Verbose", "verbose", &verbose,
+			false, gofigure.Flag, gofigure.ReportValue, "verbose"))
+		config.Command("serve", "serve").Group("server").Add(gofigure.Optional("Port",
+			"port", &port, 80, gofigure.Flag, gofigure.ReportValue, "port"))
+
+		assert.NoError(t, config.ParseUsing([]string{"serve", "--port", "8080"}))
+
+		report := config.Report()
+
+		assert.Len(t, report, 2)
+		assert.Equal(t, "server", report[1].Name)
+		assert.Equal(t, "8080", report[1].Values["Port"])
+		assert.Contains(t, report.Logfmt(0), "group=server setting=Port value=8080")
+	})
+}
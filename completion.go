@@ -0,0 +1,504 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Completion hints for the value of a Setting. Choices are offered as static
+// completions, Files will complete file paths, Directories will complete only
+// directories, and Dynamic completions are requested from the program at
+// completion time using CompleteCommand.
+type Completion struct {
+	Choices     []string
+	Files       bool
+	Directories bool
+	Dynamic     func(prefix string) []string
+}
+
+// Shell that a completion script can be generated for.
+type Shell string
+
+// completionFlag holds the completion details of a single Setting.
+type completionFlag struct {
+	long        string
+	short       string
+	description string
+	value       bool
+	completion  Completion
+}
+
+// completionScope holds the flags and sub commands available at a given
+// point in the Command tree.
+type completionScope struct {
+	path     string
+	flags    []completionFlag
+	commands []*Command
+}
+
+// ErrUnsupportedShell is returned if a completion script is requested for an
+// unknown Shell.
+var ErrUnsupportedShell = errors.New("unsupported shell")
+
+// ErrCompleting is returned by ParseUsing and Dispatch when the program was
+// called using CompleteCommand.
+var ErrCompleting = errors.New("completing")
+
+// Supported shells.
+const (
+	Bash = Shell("bash")
+	Zsh  = Shell("zsh")
+	Fish = Shell("fish")
+)
+
+// CompleteCommand is the hidden command used by completion scripts to request
+// completions from the program. When it is the first argument ParseUsing will
+// set Completing and Completions on the Configuration and return ErrCompleting
+// without applying any Settings. The program should print the Completions, one
+// per line, and exit.
+const CompleteCommand = "__complete"
+
+// Complete sets the completion hints for this Setting, returning the Setting.
+func (s *Setting) Complete(completion Completion) *Setting {
+	s.Completion = completion
+
+	return s
+}
+
+// completion hints for the Setting. External and Path settings will always
+// complete file paths, or directories if validated using IsDir, and any
+// Choices from the Setting's Validators are included.
+func (s Setting) completion() Completion {
+	completion := s.Completion
+
+	for _, validator := range s.Validators {
+		completion.Choices = append(completion.Choices, validator.Choices...)
+		completion.Directories = completion.Directories || validator.directory
+	}
+
+	switch s.Value.Ptr.(type) {
+	case *External, *Path:
+		completion.Files = true
+	}
+
+	return completion
+}
+
+// Complete returns the completions for the given arguments. The last argument
+// is the word being completed, and the arguments should not include the
+// program name. Completions are returned for flag values, flags, and sub
+// commands, in that order of preference.
+func (c *Configuration) Complete(args []string) []string {
+	var (
+		current    string
+		candidates []string
+	)
+
+	if len(args) > 0 {
+		current = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+
+	cmd, rest := c.route(args)
+	scope := c.completionScope(cmd)
+
+	if len(rest) > 0 && strings.HasPrefix(rest[len(rest)-1], "-") {
+		if f, ok := scope.flag(rest[len(rest)-1]); ok && f.value {
+			candidates = append(candidates, f.completion.Choices...)
+
+			if f.completion.Dynamic != nil {
+				candidates = append(candidates, f.completion.Dynamic(current)...)
+			}
+
+			return filter(candidates, current)
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(current, "-"):
+		for _, f := range scope.flags {
+			candidates = append(candidates, f.names()...)
+		}
+	case len(rest) == 0:
+		for _, sub := range scope.commands {
+			candidates = append(candidates, sub.Name)
+		}
+	}
+
+	return filter(candidates, current)
+}
+
+// CompletionScript for the given Shell. The program name is the name the
+// program is invoked with, and is used to call back into the program for
+// dynamic completions.
+func (c *Configuration) CompletionScript(shell Shell, program string) (string, error) {
+	scopes := c.completionScopes(nil)
+
+	switch shell {
+	case Bash:
+		return bashCompletion(program, scopes), nil
+	case Zsh:
+		return zshCompletion(program, scopes), nil
+	case Fish:
+		return fishCompletion(program, scopes), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedShell, shell)
+	}
+}
+
+// completionScopes returns the scope for the given Command and all Commands
+// below it.
+func (c *Configuration) completionScopes(cmd *Command) []completionScope {
+	scopes := []completionScope{c.completionScope(cmd)}
+
+	for _, sub := range scopes[0].commands {
+		scopes = append(scopes, c.completionScopes(sub)...)
+	}
+
+	return scopes
+}
+
+func (c *Configuration) completionScope(cmd *Command) completionScope {
+	scope := completionScope{path: cmd.String(), commands: c.Commands}
+
+	if cmd != nil {
+		scope.commands = cmd.Commands
+	}
+
+	for _, s := range c.scopes(cmd) {
+		for _, group := range s.groups {
+			for _, setting := range group.Settings {
+				if f, ok := newCompletionFlag(setting); ok {
+					scope.flags = append(scope.flags, f)
+				}
+			}
+		}
+	}
+
+	return scope
+}
+
+func (s completionScope) flag(name string) (completionFlag, bool) {
+	for _, f := range s.flags {
+		for _, n := range f.names() {
+			if n == name {
+				return f, true
+			}
+		}
+	}
+
+	return completionFlag{}, false
+}
+
+func newCompletionFlag(setting *Setting) (completionFlag, bool) {
+	_, isBool := setting.Value.Ptr.(*bool)
+
+	f := completionFlag{
+		description: setting.Value.Description,
+		value:       !isBool,
+		completion:  setting.completion(),
+	}
+
+	for _, parameter := range setting.Parameters {
+		switch parameter.Source {
+		case Flag:
+			f.long = parameter.Name
+		case ShortFlag:
+			f.short = parameter.Name
+		default:
+		}
+	}
+
+	return f, f.long != "" || f.short != ""
+}
+
+func (f completionFlag) names() []string {
+	var names []string
+
+	if f.long != "" {
+		names = append(names, "--"+f.long)
+	}
+
+	if f.short != "" {
+		names = append(names, "-"+f.short)
+	}
+
+	return names
+}
+
+func filter(candidates []string, prefix string) []string {
+	var filtered []string
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			filtered = append(filtered, candidate)
+		}
+	}
+
+	sort.Strings(filtered)
+
+	return filtered
+}
+
+// bashPreamble starts the bash completion function for a program, finding the
+// Command path in the words before the first flag.
+const bashPreamble = `# bash completion for %s
+%s() {
+    local cur prev cmd word i words
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    cmd=""
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        word="${COMP_WORDS[i]}"
+        case "$word" in -*) break ;; esac
+        cmd="${cmd:+$cmd }$word"
+    done
+    case "$cmd" in
+`
+
+// zshPreamble starts the zsh completion function for a program, finding the
+// Command path in the words before the first flag.
+const zshPreamble = `#compdef %s
+
+%s() {
+    local cur prev cmd word i
+    local -a options
+    cur="${words[CURRENT]}"
+    prev="${words[CURRENT-1]}"
+    cmd=""
+    for ((i = 2; i < CURRENT; i++)); do
+        word="${words[i]}"
+        [[ "$word" == -* ]] && break
+        cmd="${cmd:+$cmd }$word"
+    done
+    case "$cmd" in
+`
+
+// fishPreamble defines the fish function used to test the Command path for a
+// program.
+const fishPreamble = `# fish completion for %s
+function %s
+    set -l words (commandline -opc)
+    set -e words[1]
+    set -l cmd
+    for word in $words
+        string match -q -- '-*' $word; and break
+        set -a cmd $word
+    end
+    test "$cmd" = "$argv"
+end
+
+`
+
+func bashCompletion(program string, scopes []completionScope) string {
+	fn := "_" + identifier(program) + "_completions"
+	b := strings.Builder{}
+
+	fmt.Fprintf(&b, bashPreamble, program, fn)
+
+	for _, scope := range scopes {
+		var words []string
+
+		fmt.Fprintf(&b, "    %s)\n", quote(scope.path))
+		b.WriteString("        case \"$prev\" in\n")
+
+		for _, f := range scope.flags {
+			words = append(words, f.names()...)
+
+			if f.value {
+				bashFlag(&b, program, f)
+			}
+		}
+
+		b.WriteString("        esac\n")
+
+		for _, sub := range scope.commands {
+			words = append(words, sub.Name)
+		}
+
+		fmt.Fprintf(&b, "        words=%s\n", quote(strings.Join(words, " ")))
+		b.WriteString("        ;;\n")
+	}
+
+	b.WriteString("    esac\n")
+	b.WriteString("    mapfile -t COMPREPLY < <(compgen -W \"$words\" -- \"$cur\")\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "complete -F %s %s\n", fn, quote(program))
+
+	return b.String()
+}
+
+// bashFlag writes the case completing the value for a flag in bash.
+func bashFlag(b *strings.Builder, program string, f completionFlag) {
+	fmt.Fprintf(b, "        %s)\n", strings.Join(f.names(), "|"))
+
+	switch {
+	case f.completion.Dynamic != nil:
+		fmt.Fprintf(b, "            mapfile -t COMPREPLY < <(%s %s "+
+			"\"${COMP_WORDS[@]:1:COMP_CWORD}\")\n",
+			quote(program), CompleteCommand)
+	case f.completion.Directories:
+		b.WriteString("            mapfile -t COMPREPLY < <(compgen -d -- \"$cur\")\n")
+	case f.completion.Files:
+		b.WriteString("            mapfile -t COMPREPLY < <(compgen -f -- \"$cur\")\n")
+	case len(f.completion.Choices) > 0:
+		fmt.Fprintf(b, "            mapfile -t COMPREPLY < <(compgen -W %s -- \"$cur\")\n",
+			quote(strings.Join(f.completion.Choices, " ")))
+	}
+
+	b.WriteString("            return\n")
+	b.WriteString("            ;;\n")
+}
+
+func zshCompletion(program string, scopes []completionScope) string {
+	fn := "_" + identifier(program)
+	b := strings.Builder{}
+
+	fmt.Fprintf(&b, zshPreamble, program, fn)
+
+	for _, scope := range scopes {
+		fmt.Fprintf(&b, "    %s)\n", quote(scope.path))
+		b.WriteString("        case \"$prev\" in\n")
+
+		for _, f := range scope.flags {
+			if f.value {
+				zshFlag(&b, program, f)
+			}
+		}
+
+		b.WriteString("        esac\n")
+		b.WriteString("        options=(\n")
+
+		for _, f := range scope.flags {
+			for _, name := range f.names() {
+				fmt.Fprintf(&b, "            %s\n", quote(name+":"+f.description))
+			}
+		}
+
+		for _, sub := range scope.commands {
+			fmt.Fprintf(&b, "            %s\n", quote(sub.Name+":"+sub.Description))
+		}
+
+		b.WriteString("        )\n")
+		b.WriteString("        ;;\n")
+	}
+
+	b.WriteString("    esac\n")
+	fmt.Fprintf(&b, "    _describe %s options\n", quote(program))
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "compdef %s %s\n", fn, quote(program))
+
+	return b.String()
+}
+
+// zshFlag writes the case completing the value for a flag in zsh.
+func zshFlag(b *strings.Builder, program string, f completionFlag) {
+	fmt.Fprintf(b, "        %s)\n", strings.Join(f.names(), "|"))
+
+	switch {
+	case f.completion.Dynamic != nil:
+		fmt.Fprintf(b, "            compadd -- ${(f)\"$(%s %s "+
+			"${words[2,CURRENT]})\"}\n", quote(program), CompleteCommand)
+	case f.completion.Directories:
+		b.WriteString("            _files -/\n")
+	case f.completion.Files:
+		b.WriteString("            _files\n")
+	case len(f.completion.Choices) > 0:
+		fmt.Fprintf(b, "            compadd -- %s\n",
+			strings.Join(quoteAll(f.completion.Choices), " "))
+	}
+
+	b.WriteString("            return\n")
+	b.WriteString("            ;;\n")
+}
+
+func fishCompletion(program string, scopes []completionScope) string {
+	fn := "__" + identifier(program) + "_using"
+	b := strings.Builder{}
+
+	fmt.Fprintf(&b, fishPreamble, program, fn)
+	fmt.Fprintf(&b, "complete -c %s -f\n", fishQuote(program))
+
+	for _, scope := range scopes {
+		prefix := fmt.Sprintf("complete -c %s -n %s", fishQuote(program),
+			fishQuote(strings.TrimSpace(fn+" "+scope.path)))
+
+		for _, f := range scope.flags {
+			b.WriteString(prefix)
+			fishFlag(&b, program, f)
+		}
+
+		for _, sub := range scope.commands {
+			fmt.Fprintf(&b, "%s -a %s -d %s\n", prefix, fishQuote(sub.Name),
+				fishQuote(sub.Description))
+		}
+	}
+
+	return b.String()
+}
+
+// fishFlag writes the options for a flag in fish.
+func fishFlag(b *strings.Builder, program string, f completionFlag) {
+	if f.long != "" {
+		fmt.Fprintf(b, " -l %s", fishQuote(f.long))
+	}
+
+	if f.short != "" {
+		fmt.Fprintf(b, " -s %s", fishQuote(f.short))
+	}
+
+	fmt.Fprintf(b, " -d %s", fishQuote(f.description))
+
+	if f.value {
+		b.WriteString(" -r")
+	}
+
+	switch {
+	case !f.value:
+	case f.completion.Dynamic != nil:
+		fmt.Fprintf(b, " -a %s", fishQuote(fmt.Sprintf(
+			"(%s %s (commandline -opc)[2..-1] (commandline -ct))",
+			program, CompleteCommand)))
+	case f.completion.Directories:
+		b.WriteString(" -a \"(__fish_complete_directories)\"")
+	case f.completion.Files:
+		b.WriteString(" -F")
+	case len(f.completion.Choices) > 0:
+		fmt.Fprintf(b, " -a %s",
+			fishQuote(strings.Join(f.completion.Choices, " ")))
+	}
+
+	b.WriteString("\n")
+}
+
+// identifier returns a shell safe function name for the program.
+func identifier(program string) string {
+	name := program[strings.LastIndex(program, "/")+1:]
+
+	return regexp.MustCompile(`\W`).ReplaceAllString(name, "_")
+}
+
+// quote a string for use in bash or zsh.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func quoteAll(s []string) []string {
+	quoted := make([]string, len(s))
+
+	for i, v := range s {
+		quoted[i] = quote(v)
+	}
+
+	return quoted
+}
+
+// fishQuote quotes a string for use in fish.
+func fishQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
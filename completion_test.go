@@ -0,0 +1,155 @@
+package gofigure_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleConfiguration_Complete() {
+	var (
+		mode    string
+		verbose bool
+		region  string
+	)
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	config.AddConfigFile(gofigure.CommandLine)
+
+	group := config.Group("settings")
+	group.Add(gofigure.Optional("Mode", "mode", &mode, "fast",
+		gofigure.AllSources, gofigure.ReportValue, "Mode of operation").Complete(
+		gofigure.Completion{Choices: []string{"fast", "safe"}}))
+	group.Add(gofigure.Optional("Verbose", "verbose", &verbose, false,
+		gofigure.Flag, gofigure.ReportValue, "Verbose output"))
+
+	config.Command("deploy", "Deploy the application").Group("deploy").Add(
+		gofigure.Optional("Region", "region", &region, "", gofigure.Flag,
+			gofigure.ReportValue, "Region to deploy to").Complete(
+			gofigure.Completion{Dynamic: func(prefix string) []string {
+				return []string{"eu-west-1", "us-east-1"}
+			}}))
+
+	fmt.Println(config.Complete([]string{"--mode", "s"}))
+	fmt.Println(config.Complete([]string{"--v"}))
+	fmt.Println(config.Complete([]string{"d"}))
+	fmt.Println(config.Complete([]string{"deploy", "--region", ""}))
+
+	err := config.ParseUsing([]string{gofigure.CompleteCommand, "-"})
+
+	if errors.Is(err, gofigure.ErrCompleting) {
+		fmt.Println(config.Completing, config.Completions)
+	}
+
+	// Output:
+	// [safe]
+	// [--verbose]
+	// [deploy]
+	// [eu-west-1 us-east-1]
+	// true [--config --mode --verbose -c -m]
+}
+
+func TestConfiguration_Complete(t *testing.T) {
+	t.Run("No arguments will complete commands", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Command("a", "a")
+		config.Command("b", "b")
+
+		assert.Equal(t, []string{"a", "b"}, config.Complete(nil))
+	})
+
+	t.Run("Commands are not offered after flags", func(t *testing.T) {
+		t.Parallel()
+
+		var v bool
+
+		config := gofigure.NewConfiguration("")
+		config.Command("a", "a")
+		config.Group("g").Add(gofigure.Optional("v", "v", &v, false,
+			gofigure.Flag, gofigure.ReportValue, "v"))
+
+		assert.Empty(t, config.Complete([]string{"--v", ""}))
+	})
+
+	t.Run("Boolean flags don't take values", func(t *testing.T) {
+		t.Parallel()
+
+		var v bool
+
+		config := gofigure.NewConfiguration("")
+		config.Group("g").Add(gofigure.Optional("v", "v", &v, false,
+			gofigure.Flag, gofigure.ReportValue, "v"))
+
+		assert.Equal(t, []string{"--v"}, config.Complete([]string{"--v", "-"}))
+	})
+}
+
+func TestConfiguration_CompletionScript(t *testing.T) {
+	setup := func() *gofigure.Configuration {
+		var port int
+
+		config := gofigure.NewConfiguration("TOOL")
+		config.AddHelp(gofigure.CommandLine)
+		config.AddConfigFile(gofigure.CommandLine)
+		config.Command("serve", "Run the server").Group("server").Add(
+			gofigure.Optional("Port", "port", &port, 80, gofigure.CommandLine,
+				gofigure.ReportValue, "Port to listen on").Complete(
+				gofigure.Completion{Choices: []string{"80", "443"}}))
+
+		return config
+	}
+
+	t.Run("Bash scripts are generated", func(t *testing.T) {
+		t.Parallel()
+
+		script, err := setup().CompletionScript(gofigure.Bash, "tool")
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "complete -F _tool_completions 'tool'")
+		assert.Contains(t, script, "words='--help -h --config -c serve'")
+		assert.Contains(t, script, "compgen -W '80 443' -- \"$cur\"")
+		assert.Contains(t, script, "--config|-c)\n            mapfile -t "+
+			"COMPREPLY < <(compgen -f -- \"$cur\")")
+	})
+
+	t.Run("Zsh scripts are generated", func(t *testing.T) {
+		t.Parallel()
+
+		script, err := setup().CompletionScript(gofigure.Zsh, "tool")
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "#compdef tool")
+		assert.Contains(t, script, "'--port:Port to listen on'")
+		assert.Contains(t, script, "'serve:Run the server'")
+		assert.Contains(t, script, "compadd -- '80' '443'")
+		assert.Contains(t, script, "_files")
+	})
+
+	t.Run("Fish scripts are generated", func(t *testing.T) {
+		t.Parallel()
+
+		script, err := setup().CompletionScript(gofigure.Fish, "tool")
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, "complete -c 'tool' -n '__tool_using serve' "+
+			"-l 'port' -s 'p' -d 'Port to listen on' -r -a '80 443'")
+		assert.Contains(t, script, "complete -c 'tool' -n '__tool_using' "+
+			"-l 'config' -s 'c' -d 'Provide configuration from an external "+
+			"JSON file' -r -F")
+		assert.Contains(t, script, "complete -c 'tool' -n '__tool_using' "+
+			"-a 'serve' -d 'Run the server'")
+	})
+
+	t.Run("Unknown shells are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := setup().CompletionScript("csh", "tool")
+
+		assert.ErrorIs(t, err, gofigure.ErrUnsupportedShell)
+	})
+}
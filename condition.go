@@ -0,0 +1,99 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Condition that can be used to make a Setting conditionally required. The
+// Description is used when rendering the Setting in Usage.
+type Condition struct {
+	Description string
+
+	test func() bool
+}
+
+// RequiredWhen makes the Setting required if any of the given Conditions are
+// met once parsing is complete, returning the Setting. A conditionally
+// required Setting must be explicitly set, and a default value will not
+// satisfy the requirement. If none of the Conditions are met the Setting is
+// not required, even if it was defined using Required.
+func (s *Setting) RequiredWhen(conditions ...Condition) *Setting {
+	s.Requirements = append(s.Requirements, conditions...)
+
+	return s
+}
+
+// IsSet returns a Condition that is met if the Setting has been explicitly set
+// by a Source other than a default value.
+func IsSet(setting *Setting) Condition {
+	return Condition{
+		Description: fmt.Sprintf("%s is set", setting.Value.Name),
+		test:        setting.Value.explicit,
+	}
+}
+
+// Equals returns a Condition that is met if the Setting has the given value.
+// The value of the Setting is converted to the type of the given value before
+// they are compared, so untyped constants can be compared with Settings of
+// any numeric or string type. Equals will panic if the Setting can never hold
+// the given value because the types are not compatible.
+func Equals[T comparable](setting *Setting, value T) Condition {
+	if _, err := convert[T](Dereference(setting.Value.Ptr)); errors.Is(err, ErrInvalidType) {
+		panic(fmt.Sprintf("cannot compare %s with %v: %v", setting.Value.Name, value, err))
+	}
+
+	return Condition{
+		Description: fmt.Sprintf("%s is %v", setting.Value.Name, value),
+		test: func() bool {
+			t, err := convert[T](Dereference(setting.Value.Ptr))
+
+			return err == nil && t == value
+		},
+	}
+}
+
+// When returns a Condition that is met if the function returns true.
+func When(description string, f func() bool) Condition {
+	return Condition{Description: description, test: f}
+}
+
+// Met returns true if the Condition is met.
+func (c Condition) Met() bool {
+	return c.test != nil && c.test()
+}
+
+// required returns an error if the Setting is required but hasn't been set.
+func (s Setting) required(prefix string) error {
+	if len(s.Requirements) == 0 {
+		if s.Value.Source == None && s.Derivation == nil {
+			return s.missing(prefix)
+		}
+
+		return nil
+	}
+
+	for _, condition := range s.Requirements {
+		if condition.Met() && !s.Value.explicit() {
+			return NewConfigError(fmt.Errorf("%w (required when %s)",
+				ErrMissingRequiredOption, condition.Description),
+				fmt.Errorf("%w: %s: required when %s", ErrMissingRequiredOption,
+					s.Parameters.Format(prefix), condition.Description),
+				s.Parameters...)
+		}
+	}
+
+	return nil
+}
+
+// requirement describes when the Setting is required.
+func (s Setting) requirement() string {
+	descriptions := make([]string, len(s.Requirements))
+
+	for i, condition := range s.Requirements {
+		descriptions[i] = condition.Description
+	}
+
+	return "required when " + strings.Join(descriptions, " or ")
+}
@@ -0,0 +1,154 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSetting_RequiredWhen() {
+	setup := func() *gofigure.Configuration {
+		var (
+			mode, cert string
+			nodes      int
+			tls        bool
+		)
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+
+		modeSetting := gofigure.Optional("Mode", "mode", &mode, "single",
+			gofigure.Flag, gofigure.ReportValue, "Run mode")
+		tlsSetting := gofigure.Optional("TLS", "tls", &tls, false,
+			gofigure.Flag, gofigure.ReportValue, "Use TLS")
+
+		settings := config.Group("settings")
+		settings.Add(modeSetting)
+		settings.Add(tlsSetting)
+		settings.Add(gofigure.Optional("Nodes", "nodes", &nodes, 0,
+			gofigure.Flag, gofigure.ReportValue, "Cluster size").
+			RequiredWhen(gofigure.Equals(modeSetting, "cluster")))
+		settings.Add(gofigure.Required("TLS Cert", "tls-cert", &cert,
+			gofigure.Flag, gofigure.ReportValue, "TLS certificate").
+			RequiredWhen(gofigure.IsSet(tlsSetting)))
+
+		return config
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"--mode", "cluster"},
+		{"--mode", "cluster", "--nodes", "3"},
+		{"--tls"},
+		{"--tls", "--tls-cert", "cert.pem"},
+	} {
+		config := setup()
+
+		if err := config.ParseUsing(args); err != nil {
+			fmt.Println(config.Format(err))
+		} else {
+			fmt.Println("ok")
+		}
+	}
+
+	fmt.Print(setup().Usage())
+
+	// Output:
+	// ok
+	// missing required option (required when Mode is cluster): [--nodes]
+	// ok
+	// missing required option (required when TLS is set): [--tls-cert]
+	// ok
+	// usage:
+	//   Mode [--mode]
+	//     Run mode (default: single)
+	//
+	//   TLS [--tls]
+	//     Use TLS (default: false)
+	//
+	//   Nodes [--nodes]
+	//     Cluster size (default: 0; required when Mode is cluster)
+	//
+	//   TLS Cert [--tls-cert]
+	//     TLS certificate (required when TLS is set)
+}
+
+func TestCondition_Met(t *testing.T) {
+	t.Run("Custom conditions use the given function", func(t *testing.T) {
+		t.Parallel()
+
+		met := false
+		condition := gofigure.When("met", func() bool { return met })
+
+		assert.False(t, condition.Met())
+
+		met = true
+
+		assert.True(t, condition.Met())
+		assert.False(t, gofigure.Condition{}.Met())
+	})
+
+	t.Run("Any condition makes a setting required", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b bool
+
+		config := gofigure.NewConfiguration("")
+		group := config.Group("group")
+		group.Add(gofigure.Optional("A", "a", &a, false, gofigure.Flag,
+			gofigure.ReportValue, "a").
+			RequiredWhen(gofigure.When("never", func() bool { return false }),
+				gofigure.When("b", func() bool { return b })))
+		group.Add(gofigure.Optional("B", "b", &b, false, gofigure.Flag,
+			gofigure.ReportValue, "b"))
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.ErrorIs(t, config.ParseUsing([]string{"--b"}),
+			gofigure.ErrMissingRequiredOption)
+	})
+	t.Run("Equals converts to the type of the value", func(t *testing.T) {
+		t.Parallel()
+
+		type mode string
+
+		var (
+			m     mode
+			nodes uint8
+		)
+
+		modeSetting := gofigure.Enum("Mode", "mode", &m, "single",
+			[]gofigure.Choice[mode]{{Name: "single", Value: "single"}, {Name: "cluster", Value: "cluster"}},
+			gofigure.Flag, gofigure.ReportValue, "mode")
+		nodesSetting := gofigure.Optional("Nodes", "nodes", &nodes, 3, gofigure.Flag,
+			gofigure.ReportValue, "nodes")
+
+		assert.False(t, gofigure.Equals(modeSetting, "cluster").Met())
+		assert.True(t, gofigure.Equals(nodesSetting, 3).Met())
+
+		m = "cluster"
+
+		assert.True(t, gofigure.Equals(modeSetting, "cluster").Met())
+		assert.Panics(t, func() { gofigure.Equals(nodesSetting, "3") })
+	})
+	t.Run("Unset settings that are not required are not validated", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mode string
+			port int
+		)
+
+		config := gofigure.NewConfiguration("")
+		group := config.Group("group")
+		modeSetting := gofigure.Optional("Mode", "mode", &mode, "single",
+			gofigure.Flag, gofigure.ReportValue, "mode")
+		group.Add(modeSetting)
+		group.Add(gofigure.Required("Port", "port", &port, gofigure.Flag,
+			gofigure.ReportValue, "port").
+			RequiredWhen(gofigure.Equals(modeSetting, "cluster")).
+			Check(gofigure.Between(1, 65535)))
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+	})
+}
@@ -10,21 +10,67 @@ import (
 // Configuration for a program.
 type Configuration struct {
 	Help   bool
+	Check  string
 	Prefix string
 	Groups []*Group
 
+	// EncryptionKey is used to decrypt encrypted values in external
+	// configuration files. It can be set directly, or using AddEncryptionKey.
+	EncryptionKey Secret
+
+	// Constraints between Settings in different Groups. Constraints between
+	// Settings in the same Group can also be added to the Group.
+	Constraints []Constraint
+
+	// Commands available to the program. The Command selected by the command
+	// line arguments is set in Selected after parsing. If CommandEnv is true
+	// then environment variable names for Settings defined on a Command will
+	// include the Command path (e.g. PREFIX_MIGRATE_UP_NAME).
+	Commands   []*Command
+	Handler    Handler
+	Selected   *Command
+	CommandEnv bool
+
+	// Completing is set if the program was called using CompleteCommand, in
+	// which case Completions will hold the completions for the arguments.
+	Completing  bool
+	Completions []string
+
+	// UnknownKeys and UnknownEnv define how config file keys, and environment
+	// variables using the Prefix, that don't match a Setting are handled. By
+	// default they are ignored. Warnings are passed to Warn, or written to the
+	// standard logger if Warn is nil.
+	UnknownKeys Strictness
+	UnknownEnv  Strictness
+	Warn        func(err error)
+
+	// CredentialDirectories are searched in order for files holding values
+	// for Credential Parameters. If nil then DefaultCredentialDirectories is
+	// used.
+	CredentialDirectories []string
+
+	// Interpolate enables the expansion of references in values for all
+	// Settings (see Settings.Interpolate). Interpolation can also be enabled
+	// for individual Settings.
+	Interpolate bool
+
 	groups   map[string]*Group
+	commands map[string]*Command
 	external External
 }
 
-// Line in a Report. The values in the Line will respect Mask settings.
+// Line in a Report. The values in the Line will respect Mask settings. Entries
+// hold the same values as Values, in the order the Settings were declared.
 type Line struct {
-	Name   string
-	Values map[string]any
+	Name    string
+	Values  map[string]any
+	Entries []Entry
 }
 
 // Report holds the setting configuration in a way that can be reported to the
-// user. Values in the Report will respect Mask settings.
+// user. Values in the Report will respect Mask settings. Lines are in the
+// order the Groups were declared, and can be rendered using Text, JSON, YAML,
+// or Logfmt.
 type Report []Line
 
 // ErrMissingRequiredOption is returned if a required option has not been set
@@ -41,19 +87,41 @@ func NewConfiguration(envPrefix string) *Configuration {
 
 // Group of Definitions for this Configuration.
 func (c *Configuration) Group(name string) *Group {
-	if c.groups == nil {
-		c.groups = map[string]*Group{}
+	return group(&c.groups, &c.Groups, name)
+}
+
+// Command returns the named top level Command, creating it if it doesn't
+// exist. Commands are selected using the leading arguments on the command
+// line (e.g. "tool migrate up --force"). Settings defined directly on the
+// Configuration are inherited by all Commands.
+func (c *Configuration) Command(name, description string) *Command {
+	return command(&c.commands, &c.Commands, c, nil, name, description)
+}
+
+// Dispatch the parsed Configuration to the Handler for the selected Command,
+// or to the Configuration Handler if no Command was selected. ErrNoHandler is
+// returned if there is no Handler to dispatch to, wrapped in a ConfigError
+// naming the Command if one was selected. ErrCompleting is returned if the
+// program was called using CompleteCommand.
+func (c *Configuration) Dispatch() error {
+	handler := c.Handler
+
+	if c.Completing {
+		return ErrCompleting
 	}
 
-	group, ok := c.groups[name]
+	if c.Selected != nil {
+		handler = c.Selected.Handler
+	}
 
-	if !ok {
-		group = &Group{Name: name}
-		c.groups[name] = group
-		c.Groups = append(c.Groups, group)
+	if handler == nil && c.Selected == nil {
+		return ErrNoHandler
+	} else if handler == nil {
+		return NewConfigError(ErrNoHandler, fmt.Errorf("%w: %q", ErrNoHandler,
+			c.Selected.String()), Parameter{Name: c.Selected.String()})
 	}
 
-	return group
+	return handler(c)
 }
 
 // AddHelp will add a "help" flag to the set of options. If ShortFlag is set on
@@ -88,67 +156,124 @@ func (c *Configuration) AddConfigFile(sources Source) {
 }
 
 // Report on the configuration, returning the values in a format that can be
-// displayed to the user. Empty groups will be stripped from the Report. Values
-// in the Report will respect the Mask setting.
+// displayed to the user. If a Command has been selected then its Groups are
+// included after those on the Configuration. Empty groups will be stripped
+// from the Report. Values in the Report will respect the Mask setting.
 func (c *Configuration) Report() Report {
 	var report Report
 
-	for _, group := range c.Groups {
-		values := group.Values()
+	for _, scope := range c.scopes(c.Selected) {
+		for _, group := range scope.groups {
+			entries := group.Entries()
+
+			if len(entries) == 0 {
+				continue
+			}
+
+			values := make(map[string]any, len(entries))
+
+			for _, entry := range entries {
+				values[entry.Name] = entry.Value
+			}
 
-		if len(values) > 0 {
-			report = append(report, Line{Name: group.Name, Values: values})
+			report = append(report, Line{Name: group.Name, Values: values, Entries: entries})
 		}
 	}
 
 	return report
 }
 
-// Usage string for this set of Options.
+// Usage string for this set of Options. If a Command has been selected then
+// the Usage for that Command is returned.
 func (c *Configuration) Usage() string {
+	return c.usage(c.Selected)
+}
+
+func (c *Configuration) usage(cmd *Command) string {
 	var options int
 
 	b := strings.Builder{}
-	b.WriteString("usage:\n")
+	b.WriteString("usage:")
 
-	for _, group := range c.Groups {
-		for _, setting := range group.Settings {
-			var base string
+	if cmd != nil {
+		b.WriteString(" ")
+		b.WriteString(cmd.String())
+	}
 
-			if len(setting.Parameters) == 0 {
-				continue
-			}
+	b.WriteString("\n")
 
-			b.WriteString("  ")
-			b.WriteString(setting.Value.Name)
-			b.WriteString(" ")
-			b.WriteString(setting.Parameters.Format(c.Prefix))
-			b.WriteString("\n    ")
-			b.WriteString(setting.Value.Description)
+	for _, scope := range c.scopes(cmd) {
+		for _, group := range scope.groups {
+			for _, setting := range group.Settings {
+				if len(setting.Parameters) == 0 {
+					continue
+				}
 
-			if setting.Value.base != nil && !setting.Mask.Contains(HideUnset) {
-				base = fmt.Sprint(setting.Value.base)
-			}
+				writeSetting(&b, setting, scope.prefix)
 
-			if base != "" {
-				b.WriteString(fmt.Sprintf(" (default: %v)", base))
-			} else if setting.Value.base == nil {
-				b.WriteString(" (required)")
+				options++
 			}
+		}
+	}
 
-			b.WriteString("\n\n")
+	writeConstraints(&b, c.constraints(cmd))
 
-			options++
-		}
+	commands := c.Commands
+
+	if cmd != nil {
+		commands = cmd.Commands
+	}
+
+	if len(commands) > 0 {
+		b.WriteString("commands:\n")
 	}
 
-	if options == 0 {
+	for _, sub := range commands {
+		b.WriteString("  ")
+		b.WriteString(sub.Name)
+		b.WriteString("\n    ")
+		b.WriteString(sub.Description)
+		b.WriteString("\n\n")
+	}
+
+	if options == 0 && len(commands) == 0 {
 		return "[no options]"
 	}
 
 	return b.String()
 }
 
+func writeConstraints(b *strings.Builder, constraints []Constraint) {
+	if len(constraints) == 0 {
+		return
+	}
+
+	b.WriteString("constraints:\n")
+
+	for _, constraint := range constraints {
+		b.WriteString("  ")
+		b.WriteString(constraint.String())
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+}
+
+func writeSetting(b *strings.Builder, setting *Setting, prefix string) {
+	b.WriteString("  ")
+	b.WriteString(setting.Value.Name)
+	b.WriteString(" ")
+	b.WriteString(setting.Parameters.Format(prefix))
+	b.WriteString("\n    ")
+	b.WriteString(setting.Value.Description)
+
+	if notes := setting.notes(); len(notes) > 0 {
+		b.WriteString(fmt.Sprintf(" (%s)", strings.Join(notes, "; ")))
+	}
+
+	b.WriteString("\n\n")
+}
+
 // Parse the Options.
 func (c *Configuration) Parse() error {
 	return c.ParseUsing(os.Args[1:])
@@ -160,37 +285,76 @@ func (c *Configuration) Parse() error {
 func (c *Configuration) ParseUsing(args []string) error {
 	settings := Settings{}
 
-	for _, group := range c.Groups {
-		settings = append(settings, group.Settings...)
+	if len(args) > 0 && args[0] == CompleteCommand {
+		c.Completing = true
+		c.Completions = c.Complete(args[1:])
+
+		return ErrCompleting
 	}
 
-	for i, setting := range settings {
-		for j := range setting.Parameters {
-			settings[i].Parameters[j].Stub = c.Prefix
+	c.Selected, args = c.route(args)
+
+	for _, scope := range c.scopes(c.Selected) {
+		for _, group := range scope.groups {
+			for _, setting := range group.Settings {
+				for i := range setting.Parameters {
+					setting.Parameters[i].Stub = scope.prefix
+				}
+
+				settings = append(settings, setting)
+			}
 		}
 	}
 
 	if flags, err := Flags(args); err != nil {
-		return fmt.Errorf("could not parse command line arguments: %w", err)
-	} else if err = settings.Map(flags); err != nil {
+		return fmt.Errorf("could not parse command line arguments: %w",
+			c.suggestCommand(err, args))
+	} else if err = settings.mapping(flags, c.Interpolate); err != nil {
 		return fmt.Errorf("invalid command line argument: %w", err)
-	} else if err = settings.Map(Environment(c.Prefix, settings)); err != nil {
+	} else if c.Check != "" {
+		return c.CheckConfig(c.Check)
+	} else if err = settings.mapping(Environment(c.Prefix, settings), c.Interpolate); err != nil {
 		return fmt.Errorf("invalid environment variable: %w", err)
+	} else if err = settings.mapping(Credentials(c.credentialDirectories(), settings),
+		c.Interpolate); err != nil {
+		return fmt.Errorf("invalid credential: %w", err)
+	} else if err = c.unknownEnvironment(); err != nil {
+		return fmt.Errorf("unknown environment variable: %w", err)
 	}
 
 	for _, path := range settings.External() {
 		if data, err := Load(path); err != nil {
 			return fmt.Errorf("failed to load external configuration: %w", err)
-		} else if err = settings.Map(data, ErrUnexpectedArgument); err != nil {
+		} else if err = c.mapKeys(settings, data); err != nil {
 			return fmt.Errorf("invalid config value: %w", err)
 		}
 	}
 
+	return c.check(settings)
+}
+
+// check the Settings once all sources have been applied, deriving defaults
+// and then checking required Settings, Validators, and Constraints.
+func (c *Configuration) check(settings Settings) error {
+	if err := settings.derive(c.Prefix); err != nil {
+		return err
+	}
+
+	for _, setting := range settings {
+		if err := setting.required(c.Prefix); err != nil {
+			return err
+		}
+	}
+
 	for _, setting := range settings {
-		if setting.Value.Source == None {
-			return NewConfigError(ErrMissingRequiredOption, fmt.Errorf("%w: %s",
-				ErrMissingRequiredOption, setting.Parameters.Format(c.Prefix)),
-				setting.Parameters...)
+		if err := setting.validate(c.Prefix); err != nil {
+			return err
+		}
+	}
+
+	for _, constraint := range c.constraints(c.Selected) {
+		if err := constraint.Check(); err != nil {
+			return err
 		}
 	}
 
@@ -200,12 +364,81 @@ func (c *Configuration) ParseUsing(args []string) error {
 // Format an error for user consumption. This will remove most of the technical
 // details and leave a simple message as to why the configuration failed.
 // Format should be used to report any errors to the user.
+// Multiple errors, such as those returned by CheckConfig, are formatted one per
+// line.
 func (c *Configuration) Format(err error) string {
 	var target ConfigError
 
+	//nolint:errorlint // Only directly joined errors are split.
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		lines := make([]string, len(errs))
+
+		for i, e := range errs {
+			lines[i] = c.Format(e)
+		}
+
+		return strings.Join(lines, "\n")
+	}
+
 	if errors.As(err, &target) {
 		return target.Format(c.Prefix)
 	}
 
 	return err.Error()
 }
+
+// scope holds the Groups defined at one level of the Command tree, along with
+// the environment variable prefix used by those Groups.
+type scope struct {
+	groups []*Group
+	prefix string
+}
+
+// scopes returns the Groups that apply to the given Command, starting with the
+// Groups defined on the Configuration. A nil Command will only return the
+// Configuration scope.
+func (c *Configuration) scopes(cmd *Command) []scope {
+	scopes := []scope{{groups: c.Groups, prefix: c.Prefix}}
+
+	for _, command := range cmd.lineage() {
+		prefix := c.Prefix
+
+		if c.CommandEnv {
+			path := strings.Join(command.Path(), "_")
+			path = strings.ToUpper(strings.ReplaceAll(path, "-", "_"))
+
+			if prefix == "" {
+				prefix = path
+			} else {
+				prefix = fmt.Sprintf("%s_%s", prefix, path)
+			}
+		}
+
+		scopes = append(scopes, scope{groups: command.Groups, prefix: prefix})
+	}
+
+	return scopes
+}
+
+// route the arguments to a Command. Leading arguments are consumed for as long
+// as they name a Command, with the remaining arguments returned.
+func (c *Configuration) route(args []string) (*Command, []string) {
+	var selected *Command
+
+	commands := c.commands
+
+	for len(args) > 0 {
+		cmd, ok := commands[args[0]]
+
+		if !ok {
+			break
+		}
+
+		selected = cmd
+		commands = cmd.commands
+		args = args[1:]
+	}
+
+	return selected, args
+}
@@ -0,0 +1,139 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Constraint between Settings. Constraints are evaluated once parsing is
+// complete, and only consider Settings that have been explicitly set by a
+// Source other than a default value.
+type Constraint struct {
+	Settings []*Setting
+
+	kind constraint
+}
+
+// constraint types.
+type constraint uint8
+
+// ErrConflictingOptions is returned if Settings that conflict with each other
+// have been set.
+var ErrConflictingOptions = errors.New("conflicting options")
+
+const (
+	requires = constraint(iota)
+	conflicts
+	exactlyOne
+)
+
+// Requires returns a Constraint where, if setting is set, all the required
+// Settings must also be set.
+func Requires(setting *Setting, required ...*Setting) Constraint {
+	return Constraint{Settings: append([]*Setting{setting}, required...), kind: requires}
+}
+
+// Conflicts returns a Constraint where at most one of the given Settings can
+// be set.
+func Conflicts(settings ...*Setting) Constraint {
+	return Constraint{Settings: settings, kind: conflicts}
+}
+
+// ExactlyOne returns a Constraint where exactly one of the given Settings must
+// be set.
+func ExactlyOne(settings ...*Setting) Constraint {
+	return Constraint{Settings: settings, kind: exactlyOne}
+}
+
+// Constrain the Settings in the Configuration.
+func (c *Configuration) Constrain(constraints ...Constraint) {
+	c.Constraints = append(c.Constraints, constraints...)
+}
+
+// Constrain the Settings in the Group.
+func (g *Group) Constrain(constraints ...Constraint) {
+	g.Constraints = append(g.Constraints, constraints...)
+}
+
+// Check the Constraint, returning a ConfigError if it is not met.
+func (c Constraint) Check() error {
+	var set, unset Settings
+
+	for _, setting := range c.Settings {
+		if setting.Value.explicit() {
+			set = append(set, setting)
+		} else {
+			unset = append(unset, setting)
+		}
+	}
+
+	switch {
+	case c.kind == requires && len(c.Settings) > 0 && c.Settings[0].Value.explicit() &&
+		len(unset) > 0:
+		return c.error(ErrMissingRequiredOption, unset)
+	case c.kind == conflicts && len(set) > 1:
+		return c.error(ErrConflictingOptions, set)
+	case c.kind == exactlyOne && len(set) > 1:
+		return c.error(ErrConflictingOptions, set)
+	case c.kind == exactlyOne && len(set) == 0:
+		return c.error(ErrMissingRequiredOption, unset)
+	}
+
+	return nil
+}
+
+func (c Constraint) String() string {
+	names := make([]string, len(c.Settings))
+
+	for i, setting := range c.Settings {
+		names[i] = setting.Value.Name
+	}
+
+	switch {
+	case len(names) == 0:
+		return "no constraint"
+	case c.kind == requires:
+		return fmt.Sprintf("%s requires %s", names[0], list(names[1:]))
+	case c.kind == conflicts && len(names) == 2:
+		return fmt.Sprintf("%s conflicts with %s", names[0], names[1])
+	case c.kind == conflicts:
+		return fmt.Sprintf("at most one of %s", strings.Join(names, ", "))
+	default:
+		return fmt.Sprintf("exactly one of %s", strings.Join(names, ", "))
+	}
+}
+
+// error for the Constraint, naming the Parameters of the given Settings.
+func (c Constraint) error(cause error, settings Settings) error {
+	var parameters Parameters
+
+	for _, setting := range settings {
+		parameters = append(parameters, setting.Parameters...)
+	}
+
+	return NewConfigError(fmt.Errorf("%w (%s)", cause, c), fmt.Errorf("%w: %s: %s",
+		cause, c, parameters.Format("")), parameters...)
+}
+
+// constraints that apply to the given Command.
+func (c *Configuration) constraints(cmd *Command) []Constraint {
+	constraints := c.Constraints
+
+	for _, scope := range c.scopes(cmd) {
+		for _, group := range scope.groups {
+			constraints = append(constraints, group.Constraints...)
+		}
+	}
+
+	return constraints
+}
+
+// list the names as a human-readable list.
+func list(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
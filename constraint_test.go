@@ -0,0 +1,151 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleConstraint() {
+	setup := func() *gofigure.Configuration {
+		var (
+			tls, anonymous bool
+			cert, user     string
+			file, url      string
+		)
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+
+		tlsSetting := gofigure.Optional("TLS", "tls", &tls, false,
+			gofigure.Flag, gofigure.ReportValue, "Use TLS")
+		certSetting := gofigure.Optional("TLS Cert", "tls-cert", &cert, "",
+			gofigure.Flag, gofigure.ReportValue, "TLS certificate")
+		userSetting := gofigure.Optional("User", "user", &user, "",
+			gofigure.Flag, gofigure.ReportValue, "User name")
+		anonymousSetting := gofigure.Optional("Anonymous", "anonymous",
+			&anonymous, false, gofigure.Flag, gofigure.ReportValue,
+			"Connect anonymously")
+
+		settings := config.Group("settings")
+		settings.Add(tlsSetting)
+		settings.Add(certSetting)
+		settings.Add(userSetting)
+		settings.Add(anonymousSetting)
+		settings.Constrain(gofigure.Requires(certSetting, tlsSetting),
+			gofigure.Conflicts(userSetting, anonymousSetting))
+
+		fileSetting := gofigure.Optional("File", "file", &file, "",
+			gofigure.Flag, gofigure.ReportValue, "Input file")
+		urlSetting := gofigure.Optional("URL", "url", &url, "",
+			gofigure.Flag, gofigure.ReportValue, "Input URL")
+
+		input := config.Group("input")
+		input.Add(fileSetting)
+		input.Add(urlSetting)
+
+		config.Constrain(gofigure.ExactlyOne(fileSetting, urlSetting))
+
+		return config
+	}
+
+	for _, args := range [][]string{
+		{"--tls-cert", "cert.pem", "--file", "in"},
+		{"--user", "me", "--anonymous", "--url", "http://in"},
+		{},
+		{"--file", "in", "--url", "http://in"},
+		{"--tls", "--tls-cert", "cert.pem", "--file", "in"},
+	} {
+		config := setup()
+
+		if err := config.ParseUsing(args); err != nil {
+			fmt.Println(config.Format(err))
+		} else {
+			fmt.Println("ok")
+		}
+	}
+
+	usage := setup().Usage()
+	fmt.Print(usage[strings.Index(usage, "constraints:"):])
+
+	// Output:
+	// missing required option (TLS Cert requires TLS): [--tls]
+	// conflicting options (User conflicts with Anonymous): [--user, --anonymous]
+	// missing required option (exactly one of File, URL): [--file, --url]
+	// conflicting options (exactly one of File, URL): [--file, --url]
+	// ok
+	// constraints:
+	//   exactly one of File, URL
+	//   TLS Cert requires TLS
+	//   User conflicts with Anonymous
+}
+
+func TestConstraint_String(t *testing.T) {
+	t.Run("Constraints describe themselves", func(t *testing.T) {
+		t.Parallel()
+
+		a := gofigure.Optional("A", "a", new(bool), false, gofigure.Flag,
+			gofigure.ReportValue, "a")
+		b := gofigure.Optional("B", "b", new(bool), false, gofigure.Flag,
+			gofigure.ReportValue, "b")
+		c := gofigure.Optional("C", "c", new(bool), false, gofigure.Flag,
+			gofigure.ReportValue, "c")
+
+		assert.Equal(t, "A requires B and C", gofigure.Requires(a, b, c).String())
+		assert.Equal(t, "at most one of A, B, C", gofigure.Conflicts(a, b, c).String())
+		assert.Equal(t, "no constraint", gofigure.Conflicts().String())
+	})
+}
+
+func TestConstraint_Check(t *testing.T) {
+	t.Run("Default values do not count as set", func(t *testing.T) {
+		t.Parallel()
+
+		a := gofigure.Optional("A", "a", new(bool), true, gofigure.Flag,
+			gofigure.ReportValue, "a")
+		b := gofigure.Optional("B", "b", new(bool), true, gofigure.Flag,
+			gofigure.ReportValue, "b")
+
+		assert.NoError(t, gofigure.Conflicts(a, b).Check())
+		assert.NoError(t, gofigure.Requires(a, b).Check())
+		assert.ErrorIs(t, gofigure.ExactlyOne(a, b).Check(),
+			gofigure.ErrMissingRequiredOption)
+	})
+
+	t.Run("Set values are checked", func(t *testing.T) {
+		t.Parallel()
+
+		a := gofigure.Optional("A", "a", new(bool), false, gofigure.Flag,
+			gofigure.ReportValue, "a")
+		b := gofigure.Optional("B", "b", new(bool), false, gofigure.Flag,
+			gofigure.ReportValue, "b")
+
+		assert.NoError(t, a.Value.Assign(true, gofigure.Flag))
+		assert.ErrorIs(t, gofigure.Requires(a, b).Check(),
+			gofigure.ErrMissingRequiredOption)
+		assert.NoError(t, gofigure.Requires(b, a).Check())
+
+		assert.NoError(t, b.Value.Assign(true, gofigure.EnvVar))
+		assert.ErrorIs(t, gofigure.Conflicts(a, b).Check(),
+			gofigure.ErrConflictingOptions)
+	})
+
+	t.Run("Constraints on unselected Commands are ignored", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		a := gofigure.Optional("A", "a", new(bool), false, gofigure.Flag,
+			gofigure.ReportValue, "a")
+		b := gofigure.Optional("B", "b", new(bool), false, gofigure.Flag,
+			gofigure.ReportValue, "b")
+		group := config.Command("cmd", "command").Group("group")
+		group.Add(a)
+		group.Add(b)
+		group.Constrain(gofigure.ExactlyOne(a, b))
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Error(t, config.ParseUsing([]string{"cmd"}))
+	})
+}
@@ -0,0 +1,59 @@
+package gofigure
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// SecretsDirectory is the conventional location for secrets mounted by Docker
+// and Kubernetes.
+const SecretsDirectory = "/run/secrets"
+
+// DefaultCredentialDirectories returns the directories searched for
+// Credentials by default: the systemd $CREDENTIALS_DIRECTORY, if set, followed
+// by SecretsDirectory.
+func DefaultCredentialDirectories() []string {
+	var directories []string
+
+	if dir := os.Getenv("CREDENTIALS_DIRECTORY"); dir != "" {
+		directories = append(directories, dir)
+	}
+
+	return append(directories, SecretsDirectory)
+}
+
+// Credentials Options defined by the Settings. Each Credential Parameter is
+// matched to a file with the same name in the first directory that contains
+// one. The value is read from the file when it is applied.
+func Credentials(directories []string, settings Settings) Options {
+	credentials := Options{}
+
+	for _, setting := range settings {
+		for _, parameter := range setting.Parameters {
+			if !parameter.Source.Contains(Credential) || parameter.Name == "" {
+				continue
+			}
+
+			for _, dir := range directories {
+				path := filepath.Join(dir, parameter.Name)
+
+				if info, err := os.Stat(path); err == nil && !info.IsDir() {
+					credentials[parameter] = file(path)
+
+					break
+				}
+			}
+		}
+	}
+
+	return credentials
+}
+
+// credentialDirectories returns the directories to search for Credentials.
+func (c *Configuration) credentialDirectories() []string {
+	if c.CredentialDirectories == nil {
+		return DefaultCredentialDirectories()
+	}
+
+	return c.CredentialDirectories
+}
@@ -0,0 +1,68 @@
+package gofigure_test
+
+import (
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentials(t *testing.T) {
+	setup := func(mask gofigure.Mask) (*gofigure.Configuration, *string) {
+		var password string
+
+		config := gofigure.NewConfiguration("GOFIGURE_CREDENTIAL")
+		config.CredentialDirectories = []string{"testdata/missing", "testdata/credentials"}
+		config.Group("database").Add(gofigure.Optional("Password", "db-password",
+			&password, "", gofigure.NamedSources|gofigure.Credential, mask,
+			"Database password"))
+
+		return config, &password
+	}
+
+	t.Run("Credentials are read from the first matching directory", func(t *testing.T) {
+		t.Parallel()
+
+		config, password := setup(gofigure.ReportValue)
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Equal(t, "hunter2", *password)
+		assert.Equal(t, map[string]any{"Password": gofigure.Set},
+			config.Report()[0].Values)
+	})
+
+	t.Run("Credentials can be revealed", func(t *testing.T) {
+		t.Parallel()
+
+		config, _ := setup(gofigure.RevealCredential)
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Equal(t, map[string]any{"Password": "hunter2"},
+			config.Report()[0].Values)
+	})
+
+	t.Run("Flags take precedence over credentials", func(t *testing.T) {
+		t.Parallel()
+
+		config, password := setup(gofigure.ReportValue)
+
+		assert.NoError(t, config.ParseUsing([]string{"--db-password", "flag"}))
+		assert.Equal(t, "flag", *password)
+	})
+
+	t.Run("Credentials take precedence over the environment", func(t *testing.T) {
+		t.Setenv("GOFIGURE_CREDENTIAL_DB_PASSWORD", "env")
+
+		config, password := setup(gofigure.ReportValue)
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Equal(t, "hunter2", *password)
+	})
+}
+
+func TestDefaultCredentialDirectories(t *testing.T) {
+	t.Setenv("CREDENTIALS_DIRECTORY", "/run/credentials/example.service")
+
+	assert.Equal(t, []string{"/run/credentials/example.service", gofigure.SecretsDirectory},
+		gofigure.DefaultCredentialDirectories())
+}
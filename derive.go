@@ -0,0 +1,114 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// Derivation computes a default value for a Setting at parse time. Derived
+// defaults are resolved after all other sources have been applied, and only
+// for Settings that have not been explicitly set. Any Settings the Derivation
+// depends on are resolved first. The Description is shown in Usage in place
+// of a literal default value.
+type Derivation struct {
+	Description string
+	Settings    []*Setting
+
+	compute func() (any, error)
+}
+
+// ErrCyclicDefault is returned if derived defaults depend on each other.
+var ErrCyclicDefault = errors.New("cyclic default")
+
+// Derive the default value for the Setting using the Derivation, returning the
+// Setting. A Required Setting with a Derivation is no longer required, since
+// a value will always be provided.
+func (s *Setting) Derive(derivation Derivation) *Setting {
+	s.Derivation = &derivation
+
+	return s
+}
+
+// Computed returns a Derivation that uses the function to compute the default
+// value. The function must return the same type as the Setting. Any Settings
+// whose final values are used by the function should be passed as dependencies
+// so they are resolved first (e.g. a metrics port defaulting to port+1).
+func Computed[T Type](description string, f func() (T, error), dependencies ...*Setting) Derivation {
+	return Derivation{
+		Description: description,
+		Settings:    dependencies,
+		compute: func() (any, error) {
+			return f()
+		},
+	}
+}
+
+// Hostname returns a Derivation for a string Setting that defaults to the
+// hostname reported by the kernel.
+func Hostname() Derivation {
+	return Computed("hostname", os.Hostname)
+}
+
+// CPUs returns a Derivation for an int Setting that defaults to the number of
+// logical CPUs usable by the current process.
+func CPUs() Derivation {
+	return Computed("number of CPUs", func() (int, error) {
+		return runtime.NumCPU(), nil
+	})
+}
+
+// derive default values for any of the Settings that haven't been explicitly
+// set, resolving dependencies first.
+func (s Settings) derive(prefix string) error {
+	state := map[*Setting]bool{}
+
+	for _, setting := range s {
+		if err := setting.derive(prefix, state); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// derive the default value for the Setting. The state records Settings being
+// resolved (false) and Settings that have been resolved (true) so cycles can be
+// detected.
+func (s *Setting) derive(prefix string, state map[*Setting]bool) error {
+	done, seen := state[s]
+
+	switch {
+	case done || s.Derivation == nil || s.Value.explicit():
+		return nil
+	case seen:
+		return NewConfigError(fmt.Errorf("%w (%s)", ErrCyclicDefault, s.Value.Name),
+			fmt.Errorf("%w: %s %s", ErrCyclicDefault, s.Value.Name,
+				s.Parameters.Format(prefix)), s.Parameters...)
+	}
+
+	state[s] = false
+
+	for _, dependency := range s.Derivation.Settings {
+		if err := dependency.derive(prefix, state); err != nil {
+			return err
+		}
+	}
+
+	value, err := s.Derivation.compute()
+
+	if err == nil {
+		err = s.Value.Assign(value, Default)
+	}
+
+	if err != nil {
+		return NewConfigError(fmt.Errorf("%w (default: %s)", ErrInvalidValue,
+			s.Derivation.Description), fmt.Errorf("%w: failed to derive %s: %w",
+			ErrInvalidValue, s.Value.Name, err), s.Parameters...)
+	}
+
+	state[s] = true
+
+	return nil
+}
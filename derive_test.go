@@ -0,0 +1,119 @@
+package gofigure_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSetting_Derive() {
+	setup := func() (*gofigure.Configuration, *int) {
+		var port, metrics int
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+
+		portSetting := gofigure.Optional("Port", "port", &port, 8080,
+			gofigure.Flag, gofigure.ReportValue, "Port to listen on")
+
+		settings := config.Group("settings")
+		settings.Add(portSetting)
+		settings.Add(gofigure.Required("Metrics Port", "metrics-port", &metrics,
+			gofigure.Flag, gofigure.ReportValue, "Port to serve metrics on").
+			Derive(gofigure.Computed("port + 1", func() (int, error) {
+				return port + 1, nil
+			}, portSetting)))
+
+		return config, &metrics
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"--port", "9000"},
+		{"--port", "9000", "--metrics-port", "9100"},
+	} {
+		config, metrics := setup()
+
+		if err := config.ParseUsing(args); err != nil {
+			fmt.Println(config.Format(err))
+		} else {
+			fmt.Println(*metrics)
+		}
+	}
+
+	config, _ := setup()
+	fmt.Print(config.Usage())
+
+	// Output:
+	// 8081
+	// 9001
+	// 9100
+	// usage:
+	//   Port [--port]
+	//     Port to listen on (default: 8080)
+	//
+	//   Metrics Port [--metrics-port]
+	//     Port to serve metrics on (default: port + 1)
+}
+
+func TestSetting_Derive(t *testing.T) {
+	t.Run("Environment defaults are resolved", func(t *testing.T) {
+		t.Parallel()
+
+		var cpus int
+
+		config := gofigure.NewConfiguration("")
+		group := config.Group("group")
+		group.Add(gofigure.Optional("CPUs", "cpus", &cpus, 1, gofigure.Flag,
+			gofigure.ReportValue, "cpus").Derive(gofigure.CPUs()))
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Equal(t, runtime.NumCPU(), cpus)
+	})
+
+	t.Run("Cycles are reported", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b int
+
+		config := gofigure.NewConfiguration("")
+		aSetting := gofigure.Required("A", "a", &a, gofigure.Flag,
+			gofigure.ReportValue, "a")
+		bSetting := gofigure.Required("B", "b", &b, gofigure.Flag,
+			gofigure.ReportValue, "b")
+		aSetting.Derive(gofigure.Computed("b", func() (int, error) {
+			return b, nil
+		}, bSetting))
+		bSetting.Derive(gofigure.Computed("a", func() (int, error) {
+			return a, nil
+		}, aSetting))
+
+		group := config.Group("group")
+		group.Add(aSetting)
+		group.Add(bSetting)
+
+		err := config.ParseUsing([]string{})
+		assert.ErrorIs(t, err, gofigure.ErrCyclicDefault)
+		assert.Equal(t, "cyclic default (A): [--a]", config.Format(err))
+
+		assert.NoError(t, config.ParseUsing([]string{"--b", "1"}))
+		assert.Equal(t, 1, a)
+	})
+
+	t.Run("Errors computing defaults are reported", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		group := config.Group("group")
+		group.Add(gofigure.Optional("A", "a", new(string), "", gofigure.Flag,
+			gofigure.ReportValue, "a").Derive(gofigure.Computed("broken",
+			func() (string, error) { return "", errors.New("broken") })))
+
+		err := config.ParseUsing([]string{})
+		assert.ErrorIs(t, err, gofigure.ErrInvalidValue)
+		assert.Equal(t, "invalid value (default: broken): [--a]", config.Format(err))
+	})
+}
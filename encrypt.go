@@ -0,0 +1,156 @@
+package gofigure
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// sealed holds an encrypted value along with the key needed to decrypt it. The
+// value is only decrypted when it is applied to a Setting.
+type sealed struct {
+	value string
+	key   Secret
+}
+
+// Encryption errors.
+var (
+	ErrInvalidKey       = errors.New("invalid encryption key")
+	ErrMissingKey       = errors.New("missing encryption key")
+	ErrDecryptionFailed = errors.New("decryption failed")
+)
+
+// EncryptedPrefix marks a value in an external configuration file as being
+// encrypted using Encrypt.
+const EncryptedPrefix = "enc:v1:"
+
+// keySize is the size of an AES-256 key.
+const keySize = 32
+
+// AddEncryptionKey will add an "encryption-key" option to the set of options,
+// bound to EncryptionKey. The key is used to decrypt values in external
+// configuration files that have been encrypted using Encrypt. The key can
+// always be given as a flag. If EnvVar or Credential is set on the sources then
+// the key can also be given using those sources, including via a file using
+// FileSuffix. All other sources are ignored.
+func (c *Configuration) AddEncryptionKey(sources Source) {
+	use := Flag | sources&(EnvVar|Credential)
+
+	g := c.Group(internalGroup)
+	g.Add(Optional("Encryption Key", "encryption-key", &c.EncryptionKey, "", use,
+		MaskValue, "Key used to decrypt encrypted configuration values"))
+}
+
+// GenerateKey returns a new random key for use with Encrypt and Decrypt.
+func GenerateKey() (Secret, error) {
+	b := make([]byte, keySize)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	return Secret(base64.StdEncoding.EncodeToString(b)), nil
+}
+
+// Encrypt the plaintext using the key, returning a value with EncryptedPrefix
+// that can be used in an external configuration file. Values are encrypted
+// using AES-256-GCM with a random nonce. The key must be created using
+// GenerateKey.
+func Encrypt(key Secret, plaintext string) (string, error) {
+	aead, err := aeadFor(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	if _, err = rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return EncryptedPrefix + base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt a value created using Encrypt.
+func Decrypt(key Secret, value string) (string, error) {
+	aead, err := aeadFor(key)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(value, EncryptedPrefix) {
+		return "", fmt.Errorf("%w: value is not encrypted", ErrDecryptionFailed)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value, EncryptedPrefix))
+
+	if err != nil || len(b) < aead.NonceSize() {
+		return "", fmt.Errorf("%w: malformed value", ErrDecryptionFailed)
+	}
+
+	plaintext, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+	}
+
+	return string(plaintext), nil
+}
+
+// seal any encrypted values in the options so they are decrypted when applied.
+// A ConfigError is returned if there are encrypted values but no key.
+func (c *Configuration) seal(options Options) (Options, error) {
+	for _, parameter := range options.sorted() {
+		value, ok := options[parameter].(string)
+
+		if !ok || !strings.HasPrefix(value, EncryptedPrefix) {
+			continue
+		}
+
+		if c.EncryptionKey == "" {
+			return options, NewConfigError(ErrMissingKey, fmt.Errorf(
+				"%w: cannot decrypt %s", ErrMissingKey, parameter), parameter)
+		}
+
+		options[parameter] = sealed{value: value, key: c.EncryptionKey}
+	}
+
+	return options, nil
+}
+
+// String returns the encrypted value, so the key is never included when the
+// value is reported.
+func (s sealed) String() string {
+	return s.value
+}
+
+// aeadFor returns the AES-GCM cipher for the key.
+func aeadFor(key Secret) (cipher.AEAD, error) {
+	b, err := base64.StdEncoding.DecodeString(key.Reveal())
+
+	if err != nil || len(b) != keySize {
+		return nil, fmt.Errorf("%w: must be %d base64 encoded bytes", ErrInvalidKey, keySize)
+	}
+
+	block, err := aes.NewCipher(b)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,169 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypt(t *testing.T) {
+	t.Run("Values can be decrypted with the same key", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := gofigure.GenerateKey()
+		assert.NoError(t, err)
+
+		value, err := gofigure.Encrypt(key, "hunter2")
+		assert.NoError(t, err)
+		assert.Contains(t, value, gofigure.EncryptedPrefix)
+		assert.NotContains(t, value, "hunter2")
+
+		plaintext, err := gofigure.Decrypt(key, value)
+		assert.NoError(t, err)
+		assert.Equal(t, "hunter2", plaintext)
+	})
+
+	t.Run("Values cannot be decrypted with a different key", func(t *testing.T) {
+		t.Parallel()
+
+		key, _ := gofigure.GenerateKey()
+		other, _ := gofigure.GenerateKey()
+		value, _ := gofigure.Encrypt(key, "hunter2")
+
+		_, err := gofigure.Decrypt(other, value)
+		assert.ErrorIs(t, err, gofigure.ErrDecryptionFailed)
+
+		_, err = gofigure.Decrypt(key, "hunter2")
+		assert.ErrorIs(t, err, gofigure.ErrDecryptionFailed)
+
+		_, err = gofigure.Decrypt(key, gofigure.EncryptedPrefix+"!")
+		assert.ErrorIs(t, err, gofigure.ErrDecryptionFailed)
+	})
+
+	t.Run("Invalid keys are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := gofigure.Encrypt("short", "hunter2")
+		assert.ErrorIs(t, err, gofigure.ErrInvalidKey)
+
+		_, err = gofigure.Decrypt("short", gofigure.EncryptedPrefix)
+		assert.ErrorIs(t, err, gofigure.ErrInvalidKey)
+	})
+}
+
+func TestConfiguration_AddEncryptionKey(t *testing.T) {
+	setup := func(t *testing.T) (*gofigure.Configuration, string, gofigure.Secret) {
+		t.Helper()
+
+		key, err := gofigure.GenerateKey()
+		assert.NoError(t, err)
+
+		value, err := gofigure.Encrypt(key, "hunter2")
+		assert.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "config.json")
+		data := fmt.Sprintf(`{"password": %q}`, value)
+		assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+		var password gofigure.Secret
+
+		config := gofigure.NewConfiguration("GOFIGURE_ENCRYPT")
+		config.AddConfigFile(gofigure.Flag)
+		config.AddEncryptionKey(gofigure.EnvVar)
+		config.Group("database").Add(gofigure.Optional("Password", "password",
+			&password, "", gofigure.Key, gofigure.MaskValue, "Database password"))
+
+		return config, path, key
+	}
+
+	t.Run("Encrypted values are decrypted using the key", func(t *testing.T) {
+		t.Parallel()
+
+		config, path, key := setup(t)
+		setting := config.Groups[1].Settings[0]
+
+		assert.NoError(t, config.ParseUsing([]string{"--config", path,
+			"--encryption-key", key.Reveal()}))
+		assert.Equal(t, "hunter2", gofigure.Dereference(setting.Value.Ptr).(gofigure.Secret).Reveal())
+		assert.Equal(t, `JSON key: "password" (encrypted)`, setting.Value.Provenance)
+	})
+
+	t.Run("Encrypted values need a key", func(t *testing.T) {
+		t.Parallel()
+
+		config, path, _ := setup(t)
+		err := config.ParseUsing([]string{"--config", path})
+
+		assert.ErrorIs(t, err, gofigure.ErrMissingKey)
+		assert.Equal(t, `missing encryption key: [JSON key: "password"]`, config.Format(err))
+	})
+
+	t.Run("The wrong key is reported without leaking the key", func(t *testing.T) {
+		t.Parallel()
+
+		config, path, _ := setup(t)
+		other, _ := gofigure.GenerateKey()
+		err := config.ParseUsing([]string{"--config", path, "--encryption-key",
+			other.Reveal()})
+
+		assert.ErrorIs(t, err, gofigure.ErrDecryptionFailed)
+		assert.NotContains(t, err.Error(), other.Reveal())
+		assert.NotContains(t, config.Format(err), other.Reveal())
+	})
+	t.Run("References to encrypted values use the decrypted value", func(t *testing.T) {
+		t.Parallel()
+
+		key, _ := gofigure.GenerateKey()
+		value, _ := gofigure.Encrypt(key, "hunter2")
+		path := filepath.Join(t.TempDir(), "config.json")
+		data := fmt.Sprintf(`{"password": %q, "dsn": "postgres://app:${password}@db"}`, value)
+		assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+		var (
+			password gofigure.Secret
+			dsn      string
+		)
+
+		config := gofigure.NewConfiguration("GOFIGURE_ENCRYPT")
+		config.Interpolate = true
+		config.AddConfigFile(gofigure.Flag)
+		config.AddEncryptionKey(gofigure.Flag)
+		group := config.Group("database")
+		group.Add(gofigure.Optional("Password", "password", &password, "",
+			gofigure.Key, gofigure.MaskValue, "Database password"))
+		group.Add(gofigure.Optional("DSN", "dsn", &dsn, "", gofigure.Key,
+			gofigure.MaskValue, "Database DSN"))
+
+		assert.NoError(t, config.ParseUsing([]string{"--config", path,
+			"--encryption-key", key.Reveal()}))
+		assert.Equal(t, "postgres://app:hunter2@db", dsn)
+		assert.Equal(t, "hunter2", password.Reveal())
+	})
+	t.Run("Decrypted values are not included in errors", func(t *testing.T) {
+		t.Parallel()
+
+		key, _ := gofigure.GenerateKey()
+		value, _ := gofigure.Encrypt(key, "hunter2")
+		path := filepath.Join(t.TempDir(), "config.json")
+		data := fmt.Sprintf(`{"pin": %q}`, value)
+		assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+		var pin int
+
+		config := gofigure.NewConfiguration("GOFIGURE_ENCRYPT")
+		config.AddConfigFile(gofigure.Flag)
+		config.AddEncryptionKey(gofigure.Flag)
+		config.Group("database").Add(gofigure.Optional("PIN", "pin", &pin, 0,
+			gofigure.Key, gofigure.MaskValue, "PIN"))
+
+		err := config.ParseUsing([]string{"--config", path, "--encryption-key", key.Reveal()})
+
+		assert.ErrorIs(t, err, gofigure.ErrInvalidValue)
+		assert.NotContains(t, err.Error(), "hunter2")
+		assert.NotContains(t, config.Format(err), "hunter2")
+	})
+}
@@ -0,0 +1,146 @@
+package gofigure
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Choice of value for an Enum Setting. The Name is used to set the value, and
+// is reported in place of the value.
+type Choice[T comparable] struct {
+	Name  string
+	Value T
+}
+
+// enum holds the names and values for an Enum Setting.
+type enum struct {
+	names  []string
+	values []any
+}
+
+// Enum Setting uses the given default value if no value is provided via its
+// parameters. The value must be set using the Name of one of the choices, which
+// is matched case-insensitively, and the Name is used in Usage, Schema, and
+// Report. Choices can map to any comparable type, including iota constants.
+// Parameters are constructed as for Optional. Enum will panic if the default
+// value is not one of the choices. If ptr is nil a new variable is allocated.
+func Enum[T comparable](name, param string, ptr *T, value T, choices []Choice[T],
+	sources Source, mask Mask, description string) *Setting {
+	s := enumSetting(name, param, ptr, choices, sources, mask, description)
+	ptr, _ = s.Value.Ptr.(*T)
+
+	if _, ok := s.Value.enum.name(value); !ok {
+		panic(fmt.Sprintf("default value %v for %s is not one of: %s", value, name,
+			strings.Join(s.Value.enum.names, ", ")))
+	}
+
+	*ptr = value
+	s.Value.base = value
+	s.Value.Source = Default
+
+	return s
+}
+
+// RequiredEnum Setting must be set via one of its Parameters using the Name of
+// one of the choices. See Enum for details.
+func RequiredEnum[T comparable](name, param string, ptr *T, choices []Choice[T],
+	sources Source, mask Mask, description string) *Setting {
+	return enumSetting(name, param, ptr, choices, sources, mask, description)
+}
+
+func enumSetting[T comparable](name, param string, ptr *T, choices []Choice[T],
+	sources Source, mask Mask, description string) *Setting {
+	e := &enum{}
+
+	if ptr == nil {
+		ptr = new(T)
+	}
+
+	for _, choice := range choices {
+		e.names = append(e.names, choice.Name)
+		e.values = append(e.values, choice.Value)
+	}
+
+	v := &Value{Name: name, Description: description, Ptr: ptr, Source: None, enum: e}
+
+	if err := v.Validate(); err != nil {
+		panic(err)
+	}
+
+	return &Setting{
+		Value:      v,
+		Parameters: NewParameters(param, sources),
+		Mask:       mask,
+		Validators: []Validator{{
+			Description: fmt.Sprintf("one of: %s", strings.Join(e.names, ", ")),
+			Choices:     e.names,
+			Schema:      map[string]any{"type": "string", "enum": e.names},
+		}},
+	}
+}
+
+// lookup the value for the given input, which can be the name of a choice or
+// its value.
+func (e *enum) lookup(value any) (any, bool) {
+	if s, ok := value.(string); ok {
+		for i, name := range e.names {
+			if strings.EqualFold(name, s) {
+				return e.values[i], true
+			}
+		}
+	}
+
+	if len(e.values) == 0 {
+		return nil, false
+	}
+
+	if coerced, err := Coerce(value, e.values[0]); err == nil {
+		value = coerced
+	}
+
+	if converted, err := Convert(value, e.values[0]); err == nil {
+		value = converted
+	}
+
+	_, ok := e.name(value)
+
+	return value, ok
+}
+
+// name of the choice with the given value.
+func (e *enum) name(value any) (string, bool) {
+	for i, v := range e.values {
+		if v == value {
+			return e.names[i], true
+		}
+	}
+
+	return "", false
+}
+
+// assign the value to the Value using the enum.
+func (v *Value) assignEnum(value any, source Source) error {
+	choice, ok := v.enum.lookup(value)
+
+	if !ok {
+		return fmt.Errorf("%w: %v is not one of: %s", ErrInvalidValue, value,
+			strings.Join(v.enum.names, ", "))
+	}
+
+	reflect.ValueOf(v.Ptr).Elem().Set(reflect.ValueOf(choice))
+	v.Source = source
+
+	return nil
+}
+
+// format the value for display, using the name of an enum choice.
+func (v *Value) format(value any) string {
+	if v.enum != nil {
+		if name, ok := v.enum.name(value); ok {
+			return name
+		}
+	}
+
+	return format(value, v.Layout)
+}
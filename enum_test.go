@@ -0,0 +1,113 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+type level int
+
+const (
+	debug level = iota
+	info
+	warn
+)
+
+var levels = []gofigure.Choice[level]{
+	{Name: "debug", Value: debug},
+	{Name: "info", Value: info},
+	{Name: "warn", Value: warn},
+}
+
+func ExampleEnum() {
+	var logging level
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	group := config.Group("logging")
+	group.Add(gofigure.Enum("Level", "level", &logging, info, levels,
+		gofigure.NamedSources, gofigure.ReportValue, "Log level"))
+
+	if err := config.ParseUsing([]string{"--level", "WARN"}); err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	fmt.Println(logging == warn)
+	fmt.Println(config.Report()[0].Values)
+	fmt.Print(config.Usage())
+
+	// Output:
+	// true
+	// map[Level:warn]
+	// usage:
+	//   Level [JSON key: "level", env EXAMPLE_LEVEL, --level]
+	//     Log level (default: info; one of: debug, info, warn)
+}
+
+func TestEnum(t *testing.T) {
+	t.Run("Unknown names are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		var logging level
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+		config.Group("logging").Add(gofigure.Enum("Level", "level", &logging, info,
+			levels, gofigure.NamedSources, gofigure.ReportValue, "Log level"))
+
+		err := config.ParseUsing([]string{"--level", "trace"})
+
+		assert.ErrorIs(t, err, gofigure.ErrInvalidValue)
+		assert.Contains(t, err.Error(), "not one of: debug, info, warn")
+	})
+
+	t.Run("Required enums must be set", func(t *testing.T) {
+		t.Parallel()
+
+		var mode string
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+		config.Group("mode").Add(gofigure.RequiredEnum("Mode", "mode", &mode,
+			[]gofigure.Choice[string]{{Name: "Fast", Value: "f"}, {Name: "Safe", Value: "s"}},
+			gofigure.NamedSources, gofigure.ReportValue, "Mode"))
+
+		assert.ErrorIs(t, config.ParseUsing(nil), gofigure.ErrMissingRequiredOption)
+
+		config = gofigure.NewConfiguration("EXAMPLE")
+		config.Group("mode").Add(gofigure.RequiredEnum("Mode", "mode", &mode,
+			[]gofigure.Choice[string]{{Name: "Fast", Value: "f"}, {Name: "Safe", Value: "s"}},
+			gofigure.NamedSources, gofigure.ReportValue, "Mode"))
+
+		assert.NoError(t, config.ParseUsing([]string{"--mode", "safe"}))
+		assert.Equal(t, "s", mode)
+	})
+
+	t.Run("Values are listed in the schema", func(t *testing.T) {
+		t.Parallel()
+
+		var logging level
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+		config.Group("logging").Add(gofigure.Enum("Level", "level", &logging, info,
+			levels, gofigure.NamedSources, gofigure.ReportValue, "Log level"))
+
+		b, err := config.Schema()
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), `"enum": [`)
+		assert.Contains(t, string(b), `"default": "info"`)
+		assert.NotContains(t, string(b), `"minimum"`)
+	})
+
+	t.Run("Invalid defaults panic", func(t *testing.T) {
+		t.Parallel()
+
+		var logging level
+
+		assert.Panics(t, func() {
+			gofigure.Enum("Level", "level", &logging, level(7), levels,
+				gofigure.NamedSources, gofigure.ReportValue, "Log level")
+		})
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"os"
 )
 
-// Environment Options defined by the Settings.
+// Environment Options defined by the Settings. The prefix is used as the Stub
+// for any Parameter that doesn't already have one. If a variable is unset but
+// the variable with FileSuffix is set then the value is read from the file.
 func Environment(prefix string, settings Settings) Options {
 	vars := Options{}
 
@@ -14,10 +16,14 @@ func Environment(prefix string, settings Settings) Options {
 				continue
 			}
 
-			parameter.Stub = prefix
+			if parameter.Stub == "" {
+				parameter.Stub = prefix
+			}
 
 			if value := os.Getenv(parameter.FullName()); value != "" {
 				vars[parameter] = value
+			} else if path := os.Getenv(parameter.FullName() + FileSuffix); path != "" {
+				vars[parameter] = file(path)
 			}
 		}
 	}
@@ -2,6 +2,7 @@ package gofigure
 
 import (
 	"fmt"
+	"strings"
 )
 
 // ConfigError holds data about what specifically caused configuration to fail.
@@ -10,8 +11,9 @@ type ConfigError struct {
 	Cause    error
 	Internal error
 
-	Parameters Parameters
-	Value      any
+	Parameters  Parameters
+	Suggestions Parameters
+	Value       any
 }
 
 // NewConfigError will return a new ConfigError for the given errors and
@@ -24,9 +26,26 @@ func NewConfigError(cause, internal error, parameters ...Parameter) ConfigError
 	}
 }
 
-// Format the error in a user-centric way.
+// Format the error in a user-centric way. Any Suggestions are included as
+// alternatives to the Parameters.
 func (c ConfigError) Format(prefix string) string {
-	return fmt.Sprintf("%s: %s", c.Cause.Error(), c.Parameters.Format(prefix))
+	msg := fmt.Sprintf("%s: %s", c.Cause.Error(), c.Parameters.Format(prefix))
+
+	if len(c.Suggestions) == 0 {
+		return msg
+	}
+
+	suggestions := make([]string, len(c.Suggestions))
+
+	for i, suggestion := range c.Suggestions {
+		if suggestion.Stub == "" {
+			suggestion.Stub = prefix
+		}
+
+		suggestions[i] = suggestion.String()
+	}
+
+	return fmt.Sprintf("%s (did you mean %s?)", msg, strings.Join(suggestions, " or "))
 }
 
 func (c ConfigError) Error() string {
@@ -0,0 +1,86 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// file holds the path to a file containing a value.
+type file string
+
+// ErrReadingFile is returned if a file containing a value cannot be read.
+var ErrReadingFile = errors.New("error reading file")
+
+const (
+	// FileSuffix is appended to the name of an environment variable to give
+	// the name of a variable holding the path to a file containing the value
+	// (e.g. PREFIX_PASSWORD_FILE). The file is only used if the variable
+	// without the suffix is unset.
+	FileSuffix = "_FILE"
+
+	// FilePrefix marks a value in an external configuration file as a path to
+	// a file containing the value (e.g. "password": "file:/run/secrets/db").
+	// FilePrefix is only used for Settings with ReadFiles set.
+	FilePrefix = "file:"
+)
+
+// resolve a value to the contents of the file it references, if any, returning
+// the value and its provenance. Key values are only treated as a reference if
+// prefixed is true. Trailing newlines are trimmed from the file. Sealed values
+// are decrypted.
+func resolve(parameter Parameter, value any, prefixed bool) (any, string, error) {
+	if s, ok := value.(sealed); ok {
+		plaintext, err := Decrypt(s.key, s.value)
+
+		if err != nil {
+			return value, "", fmt.Errorf("failed to decrypt %s: %w", parameter, err)
+		}
+
+		return plaintext, parameter.String() + " (encrypted)", nil
+	}
+
+	path, ok := value.(file)
+
+	if s, isString := value.(string); !ok && isString && prefixed && parameter.Source == Key &&
+		strings.HasPrefix(s, FilePrefix) {
+		path, ok = file(strings.TrimPrefix(s, FilePrefix)), true
+	}
+
+	if !ok {
+		return value, parameter.String(), nil
+	}
+
+	b, err := os.ReadFile(string(path)) //nolint:gosec // Reading the file is the point.
+
+	if err != nil {
+		return value, "", fmt.Errorf("%w for %s: %w", ErrReadingFile, parameter, err)
+	}
+
+	return strings.TrimRight(string(b), "\r\n"), fmt.Sprintf("file: %s", path), nil
+}
+
+// prefixed returns true if Key values for the Setting with FilePrefix should be
+// read from the file. URL and Path values are never read as they can
+// legitimately start with FilePrefix.
+func (s Setting) prefixed() bool {
+	switch s.Value.Ptr.(type) {
+	case **url.URL, *Path:
+		return false
+	default:
+		return s.ReadFiles
+	}
+}
+
+// hidden returns true if the resolved value was decrypted or read from a file,
+// in which case it must not be included in errors.
+func hidden(value, resolved any) bool {
+	switch value.(type) {
+	case sealed, file:
+		return true
+	default:
+		return value != resolved
+	}
+}
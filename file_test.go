@@ -0,0 +1,99 @@
+package gofigure_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:paralleltest // Setting environment variables.
+func TestSettings_Apply_File(t *testing.T) {
+	setup := func() (*gofigure.Configuration, *gofigure.Setting) {
+		var password string
+
+		config := gofigure.NewConfiguration("GOFIGURE_FILE")
+		config.AddConfigFile(gofigure.Flag)
+		setting := gofigure.Optional("Password", "password", &password, "",
+			gofigure.Key|gofigure.EnvVar, gofigure.MaskValue, "Password")
+		config.Group("settings").Add(setting)
+
+		return config, setting
+	}
+
+	t.Run("Environment variables can reference files", func(t *testing.T) {
+		t.Setenv("GOFIGURE_FILE_PASSWORD_FILE", "testdata/password.txt")
+
+		config, setting := setup()
+		config.UnknownEnv = gofigure.RejectUnknown
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Equal(t, "secret", gofigure.Dereference(setting.Value.Ptr))
+		assert.Equal(t, "file: testdata/password.txt", setting.Value.Provenance)
+	})
+
+	t.Run("Environment variables take precedence over files", func(t *testing.T) {
+		t.Setenv("GOFIGURE_FILE_PASSWORD", "value")
+		t.Setenv("GOFIGURE_FILE_PASSWORD_FILE", "testdata/password.txt")
+
+		config, setting := setup()
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Equal(t, "value", gofigure.Dereference(setting.Value.Ptr))
+		assert.Equal(t, "env GOFIGURE_FILE_PASSWORD", setting.Value.Provenance)
+	})
+
+	t.Run("Config file values can reference files", func(t *testing.T) {
+		config, setting := setup()
+		setting.ReadFiles = true
+
+		assert.NoError(t, config.ParseUsing([]string{"--config", "testdata/secrets.json"}))
+		assert.Equal(t, "secret", gofigure.Dereference(setting.Value.Ptr))
+		assert.Equal(t, "file: testdata/password.txt", setting.Value.Provenance)
+	})
+
+	t.Run("Config file values are only read from files if enabled", func(t *testing.T) {
+		var (
+			dsn      string
+			endpoint *url.URL
+		)
+
+		config := gofigure.NewConfiguration("GOFIGURE_FILE")
+		config.AddConfigFile(gofigure.Flag)
+		group := config.Group("settings")
+		group.Add(gofigure.Optional("DSN", "dsn", &dsn, "", gofigure.Key,
+			gofigure.ReportValue, "Database DSN"))
+		group.Add(gofigure.Optional("Endpoint", "endpoint", &endpoint, nil,
+			gofigure.Key, gofigure.ReportValue, "Endpoint"))
+		group.Settings[1].ReadFiles = true
+
+		assert.NoError(t, config.ParseUsing([]string{"--config", "testdata/uris.json"}))
+		assert.Equal(t, "file:test.db?cache=shared", dsn)
+		assert.Equal(t, "file:testdata/password.txt", endpoint.String())
+	})
+
+	t.Run("Values read from files are not included in errors", func(t *testing.T) {
+		t.Setenv("GOFIGURE_FILE_PIN_FILE", "testdata/password.txt")
+
+		var pin int
+
+		config := gofigure.NewConfiguration("GOFIGURE_FILE")
+		config.Group("settings").Add(gofigure.Optional("PIN", "pin", &pin, 0,
+			gofigure.EnvVar, gofigure.MaskValue, "PIN"))
+
+		err := config.ParseUsing([]string{})
+
+		assert.ErrorIs(t, err, gofigure.ErrInvalidValue)
+		assert.NotContains(t, err.Error(), "secret")
+		assert.Contains(t, err.Error(), "file: testdata/password.txt")
+	})
+
+	t.Run("Missing files are reported", func(t *testing.T) {
+		t.Setenv("GOFIGURE_FILE_PASSWORD_FILE", "testdata/missing.txt")
+
+		config, _ := setup()
+
+		assert.ErrorIs(t, config.ParseUsing([]string{}), gofigure.ErrReadingFile)
+	})
+}
@@ -1,9 +1,10 @@
 package gofigure
 
-// Group of Settings.
+// Group of Settings, along with any Constraints between them.
 type Group struct {
-	Name     string
-	Settings []*Setting
+	Name        string
+	Settings    []*Setting
+	Constraints []Constraint
 }
 
 // Add a Setting to the Group.
@@ -16,11 +17,25 @@ func (g *Group) Add(setting *Setting) {
 func (g *Group) Values() map[string]any {
 	values := map[string]any{}
 
+	for _, entry := range g.Entries() {
+		values[entry.Name] = entry.Value
+	}
+
+	return values
+}
+
+// Entries for the Settings in this group, in the order they were added.
+// Entries will strip any Setting with a Mask that indicates it should be
+// hidden.
+func (g *Group) Entries() []Entry {
+	var entries []Entry
+
 	for _, setting := range g.Settings {
 		if value, ok := setting.Display(); ok {
-			values[setting.Value.Name] = value
+			entries = append(entries, Entry{Name: setting.Value.Name, Value: value,
+				Source: setting.Value.Source, Provenance: setting.Value.Provenance})
 		}
 	}
 
-	return values
+	return entries
 }
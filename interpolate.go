@@ -0,0 +1,226 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// interpolation state used when expanding references in option values.
+type interpolation struct {
+	settings  Settings
+	options   map[Parameter]any
+	resolving []*Setting
+	names     []string
+	all       bool
+}
+
+// Interpolation errors.
+var (
+	ErrCyclicReference   = errors.New("cyclic reference")
+	ErrUndefinedVariable = errors.New("undefined variable")
+	ErrInvalidReference  = errors.New("invalid reference")
+)
+
+// Interpolate returns a copy of the options with any references in string
+// values expanded. Only values for Settings with Interpolate set are expanded.
+//
+// A reference of the form ${name} is replaced with the value of the Setting
+// with a Key or Flag Parameter called name, or with the environment variable
+// called name if there is no such Setting. Values for the referenced Setting
+// in the options take precedence over its current value. ${name:-default} will
+// use the default if the reference is unset or empty, and $$ is used for a
+// literal $. References to encrypted values are decrypted. A ConfigError is
+// returned if a reference is undefined, or if references form a cycle.
+func (s Settings) Interpolate(options map[Parameter]any) (Options, error) {
+	return s.interpolate(options, false)
+}
+
+// interpolate the options as for Interpolate. If all is true then values for
+// all Settings are expanded, regardless of whether they have Interpolate set.
+func (s Settings) interpolate(options map[Parameter]any, all bool) (Options, error) {
+	expanded := make(Options, len(options))
+
+	for parameter, value := range options {
+		expanded[parameter] = value
+	}
+
+	for _, parameter := range expanded.sorted() {
+		setting, ok := s.accepting(parameter)
+		value, isString := options[parameter].(string)
+
+		if !ok || !isString || !(all || setting.Interpolate) {
+			continue
+		}
+
+		i := &interpolation{settings: s, options: options, all: all,
+			resolving: []*Setting{setting}, names: []string{parameter.Name}}
+
+		result, err := i.expand(value)
+
+		if err != nil {
+			return nil, NewConfigError(err, fmt.Errorf("failed to interpolate %s: %w",
+				parameter, err), parameter)
+		}
+
+		expanded[parameter] = result
+	}
+
+	return expanded, nil
+}
+
+// accepting returns the Setting that Accepts the Parameter.
+func (s Settings) accepting(parameter Parameter) (*Setting, bool) {
+	for _, setting := range s {
+		if setting.Accepts(parameter) {
+			return setting, true
+		}
+	}
+
+	return nil, false
+}
+
+// named returns the Setting with a Key or Flag Parameter with the given name.
+func (s Settings) named(name string) (*Setting, bool) {
+	for _, setting := range s {
+		for _, parameter := range setting.Parameters {
+			if parameter.Name == name && parameter.Source.Contains(Key|Flag) {
+				return setting, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// expand all references in the value.
+func (i *interpolation) expand(value string) (string, error) {
+	b := strings.Builder{}
+
+	for n := 0; n < len(value); n++ {
+		switch {
+		case value[n] != '$' || n+1 == len(value):
+			b.WriteByte(value[n])
+		case value[n+1] == '$':
+			b.WriteByte('$')
+			n++
+		case value[n+1] == '{':
+			end := closing(value, n+2)
+
+			if end < 0 {
+				return "", fmt.Errorf("%w (unterminated %s)", ErrInvalidReference, value[n:])
+			}
+
+			result, err := i.resolve(value[n+2 : end])
+
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(result)
+
+			n = end
+		default:
+			b.WriteByte('$')
+		}
+	}
+
+	return b.String(), nil
+}
+
+// resolve a single reference, using any default if the reference is unset or
+// empty.
+func (i *interpolation) resolve(reference string) (string, error) {
+	name, fallback, hasDefault := strings.Cut(reference, ":-")
+
+	if name == "" {
+		return "", fmt.Errorf("%w (${%s})", ErrInvalidReference, reference)
+	}
+
+	value, ok, err := i.lookup(name)
+
+	switch {
+	case err != nil:
+		return "", err
+	case (!ok || value == "") && hasDefault:
+		return i.expand(fallback)
+	case !ok:
+		return "", fmt.Errorf("%w (%s)", ErrUndefinedVariable, name)
+	default:
+		return value, nil
+	}
+}
+
+// lookup the named Setting, falling back to the environment.
+func (i *interpolation) lookup(name string) (string, bool, error) {
+	setting, ok := i.settings.named(name)
+
+	if !ok {
+		value, ok := os.LookupEnv(name)
+
+		return value, ok, nil
+	}
+
+	for n, s := range i.resolving {
+		if s == setting {
+			cycle := append(append([]string{}, i.names[n:]...), name)
+
+			return "", false, fmt.Errorf("%w (%s)", ErrCyclicReference,
+				strings.Join(cycle, " -> "))
+		}
+	}
+
+	for _, parameter := range Options(i.options).sorted() {
+		if !setting.Accepts(parameter) {
+			continue
+		}
+
+		value := i.options[parameter]
+
+		if s, ok := value.(sealed); ok {
+			plaintext, err := Decrypt(s.key, s.value)
+
+			return plaintext, err == nil, err
+		}
+
+		if s, ok := value.(string); ok && (i.all || setting.Interpolate) {
+			i.resolving = append(i.resolving, setting)
+			i.names = append(i.names, name)
+			expanded, err := i.expand(s)
+			i.resolving = i.resolving[:len(i.resolving)-1]
+			i.names = i.names[:len(i.names)-1]
+
+			return expanded, true, err
+		}
+
+		return plain(value), true, nil
+	}
+
+	if setting.Value.Source == None {
+		return "", false, nil
+	}
+
+	return plain(Dereference(setting.Value.Ptr)), true, nil
+}
+
+// closing returns the index of the brace that closes the reference starting at
+// the given index, or -1 if the reference isn't closed.
+func closing(value string, start int) int {
+	depth := 1
+
+	for n := start; n < len(value); n++ {
+		switch value[n] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+
+		if depth == 0 {
+			return n
+		}
+	}
+
+	return -1
+}
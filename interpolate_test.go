@@ -0,0 +1,157 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSettings_Interpolate() {
+	var (
+		host, url, price string
+		port             int
+	)
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	config.Interpolate = true
+	config.AddConfigFile(gofigure.Flag)
+
+	settings := config.Group("settings")
+	settings.Add(gofigure.Optional("Host", "host", &host, "localhost",
+		gofigure.Flag|gofigure.Key, gofigure.ReportValue, "Host name"))
+	settings.Add(gofigure.Optional("Port", "port", &port, 80,
+		gofigure.Flag|gofigure.Key, gofigure.ReportValue, "Port"))
+	settings.Add(gofigure.Optional("URL", "url", &url, "",
+		gofigure.Flag|gofigure.Key, gofigure.ReportValue, "API URL"))
+	settings.Add(gofigure.Optional("Price", "price", &price, "",
+		gofigure.Key, gofigure.ReportValue, "Price"))
+
+	err := config.ParseUsing([]string{"--config", "testdata/interpolate.json",
+		"--port", "9000"})
+
+	if err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	fmt.Println(url)
+	fmt.Println(price)
+
+	// Output:
+	// http://example.com:9000/api
+	// $5
+}
+
+func TestSettings_Interpolate(t *testing.T) {
+	setup := func() gofigure.Settings {
+		var a, b, c string
+
+		settings := gofigure.Settings{
+			gofigure.Optional("A", "a", &a, "", gofigure.Flag, gofigure.ReportValue, "a"),
+			gofigure.Optional("B", "b", &b, "", gofigure.Flag, gofigure.ReportValue, "b"),
+			gofigure.Optional("C", "c", &c, "", gofigure.Flag, gofigure.ReportValue, "c"),
+		}
+
+		for _, setting := range settings {
+			setting.Interpolate = true
+		}
+
+		return settings
+	}
+
+	flag := func(name string) gofigure.Parameter {
+		return gofigure.Parameter{Name: name, Source: gofigure.Flag}
+	}
+
+	t.Run("Environment variables are expanded", func(t *testing.T) {
+		t.Setenv("GOFIGURE_TEST_VAR", "value")
+
+		options, err := setup().Interpolate(gofigure.Options{
+			flag("a"): "${GOFIGURE_TEST_VAR}",
+			flag("b"): "${GOFIGURE_TEST_UNSET:-${GOFIGURE_TEST_VAR}}",
+			flag("c"): "$${GOFIGURE_TEST_VAR} $x $",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "value", options[flag("a")])
+		assert.Equal(t, "value", options[flag("b")])
+		assert.Equal(t, "${GOFIGURE_TEST_VAR} $x $", options[flag("c")])
+	})
+
+	t.Run("Settings without interpolation are left as is", func(t *testing.T) {
+		t.Parallel()
+
+		settings := setup()
+		settings[0].Interpolate = false
+
+		options, err := settings.Interpolate(gofigure.Options{flag("a"): "${b}"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "${b}", options[flag("a")])
+	})
+
+	t.Run("Settings are referenced using their pending values", func(t *testing.T) {
+		t.Parallel()
+
+		settings := setup()
+
+		assert.NoError(t, settings.Map(gofigure.Options{
+			flag("a"): "${b}/${c}",
+			flag("b"): "${c}",
+			flag("c"): "c",
+		}))
+
+		assert.Equal(t, "c/c", gofigure.Dereference(settings[0].Value.Ptr))
+	})
+
+	t.Run("Cycles are reported", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		err := setup().Map(gofigure.Options{
+			flag("a"): "${b}",
+			flag("b"): "${c}",
+			flag("c"): "${a}",
+		})
+
+		assert.ErrorIs(t, err, gofigure.ErrCyclicReference)
+		assert.Equal(t, "cyclic reference (a -> b -> c -> a): [--a]", config.Format(err))
+	})
+
+	t.Run("Invalid references are reported", func(t *testing.T) {
+		t.Parallel()
+
+		for value, expected := range map[string]error{
+			"${GOFIGURE_TEST_UNDEFINED}": gofigure.ErrUndefinedVariable,
+			"${b":                        gofigure.ErrInvalidReference,
+			"${:-x}":                     gofigure.ErrInvalidReference,
+		} {
+			_, err := setup().Interpolate(gofigure.Options{flag("a"): value})
+
+			assert.ErrorIs(t, err, expected)
+		}
+	})
+}
+
+func TestConfiguration_Interpolate(t *testing.T) {
+	t.Run("Settings are not changed by the configuration", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b string
+
+		settings := []*gofigure.Setting{
+			gofigure.Optional("A", "a", &a, "", gofigure.Flag, gofigure.ReportValue, "a"),
+			gofigure.Optional("B", "b", &b, "x", gofigure.Flag, gofigure.ReportValue, "b"),
+		}
+
+		config := gofigure.NewConfiguration("")
+		config.Interpolate = true
+		config.Group("settings").Settings = settings
+
+		assert.NoError(t, config.ParseUsing([]string{"--a", "${b}"}))
+		assert.Equal(t, "x", a)
+		assert.False(t, settings[0].Interpolate)
+		assert.False(t, settings[1].Interpolate)
+	})
+}
@@ -1,6 +1,7 @@
 package gofigure
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -21,6 +22,9 @@ var ErrParsingJSON = errors.New("error parsing JSON")
 // ErrLoadingConfig is given to a ConfigError if Load fails.
 var ErrLoadingConfig = errors.New("error loading config")
 
+// ErrUnexpectedData is returned if there is data after the JSON object.
+var ErrUnexpectedData = errors.New("unexpected data after JSON object")
+
 // Load external Options from a URI. The external file can be any JSON
 // object.
 func Load(uri string) (Options, error) {
@@ -41,7 +45,8 @@ func Load(uri string) (Options, error) {
 	return options, nil
 }
 
-// Get a JSON object from an external source.
+// Get a JSON object from an external source. Numbers are returned as
+// json.Number so large integers are not rounded before they are assigned.
 func Get(uri string) (map[string]any, error) {
 	var data map[string]any
 
@@ -53,13 +58,29 @@ func Get(uri string) (map[string]any, error) {
 
 	if b, err := f(uri); err != nil {
 		return data, fmt.Errorf("%w from %q: %s", ErrLoadingJSON, uri, err.Error())
-	} else if err = json.Unmarshal(b, &data); err != nil {
+	} else if err = decode(b, &data); err != nil {
 		return data, fmt.Errorf("%w %q: %s", ErrParsingJSON, uri, err.Error())
 	}
 
 	return data, nil
 }
 
+// decode the JSON object, using json.Number for numbers.
+func decode(b []byte, data *map[string]any) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(data); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid JSON: %w", ErrUnexpectedData)
+	}
+
+	return nil
+}
+
 func get(uri string) ([]byte, error) {
 	ctx := context.Background()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
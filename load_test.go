@@ -2,6 +2,7 @@ package gofigure_test
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -23,6 +24,54 @@ func ExampleLoad() {
 	// [address:localhost:8000, duration:1h, float:0, int:0, name:overridden]
 }
 
+func TestLoad(t *testing.T) {
+	t.Run("Large integers are loaded exactly", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			id    int64
+			limit uint64
+			count int
+			ratio float32
+		)
+
+		config := gofigure.NewConfiguration("")
+		config.AddConfigFile(gofigure.Flag)
+		group := config.Group("numbers")
+		group.Add(gofigure.Optional("ID", "id", &id, 0, gofigure.Key,
+			gofigure.ReportValue, "id"))
+		group.Add(gofigure.Optional("Limit", "limit", &limit, 0, gofigure.Key,
+			gofigure.ReportValue, "limit"))
+		group.Add(gofigure.Optional("Count", "count", &count, 0, gofigure.Key,
+			gofigure.ReportValue, "count"))
+		group.Add(gofigure.Optional("Ratio", "ratio", &ratio, 0, gofigure.Key,
+			gofigure.ReportValue, "ratio"))
+
+		assert.NoError(t, config.ParseUsing([]string{"--config", "testdata/numbers.json"}))
+		assert.Equal(t, int64(9007199254740993), id)
+		assert.Equal(t, uint64(math.MaxUint64), limit)
+		assert.Equal(t, 1000, count)
+		assert.Equal(t, float32(0.25), ratio)
+	})
+
+	t.Run("Numbers are range checked", func(t *testing.T) {
+		t.Parallel()
+
+		var id int32
+
+		config := gofigure.NewConfiguration("")
+		config.AddConfigFile(gofigure.Flag)
+		config.Group("numbers").Add(gofigure.Optional("ID", "id", &id, 0,
+			gofigure.Key, gofigure.ReportValue, "id"))
+
+		err := config.ParseUsing([]string{"--config", "testdata/numbers.json"})
+
+		assert.ErrorIs(t, err, gofigure.ErrInvalidValue)
+		assert.Equal(t, `invalid value '9007199254740993': [JSON key: "id"]`,
+			config.Format(err))
+	})
+}
+
 func TestGet(t *testing.T) {
 	t.Run("An error calling the target will be reported", func(t *testing.T) {
 		t.Parallel()
@@ -65,4 +114,17 @@ func TestGet(t *testing.T) {
 
 		assert.ErrorIs(t, err, gofigure.ErrParsingJSON)
 	})
+
+	t.Run("Trailing data will fail", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"key": 1} {}`))
+			}))
+
+		_, err := gofigure.Get(server.URL)
+
+		assert.ErrorIs(t, err, gofigure.ErrParsingJSON)
+	})
 }
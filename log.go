@@ -0,0 +1,165 @@
+package gofigure
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ReportLogger logs a Report using slog. The first Report logged is logged in
+// full, and each subsequent Report only logs the values that have changed, so
+// the effective configuration can be logged at startup and on each reload.
+// Values respect the Mask on each Setting.
+type ReportLogger struct {
+	Logger *slog.Logger
+	Level  slog.Level
+
+	last Report
+}
+
+// NewReportLogger returns a ReportLogger that logs to the given Logger at
+// slog.LevelInfo. If logger is nil then slog.Default is used.
+func NewReportLogger(logger *slog.Logger) *ReportLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ReportLogger{Logger: logger, Level: slog.LevelInfo}
+}
+
+// LogValue returns the Report as a group for each Line holding the masked
+// value of each Setting, in declaration order.
+func (r Report) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(r))
+
+	for _, l := range r {
+		values := make([]any, len(l.Entries))
+
+		for i, entry := range l.Entries {
+			values[i] = slog.String(entry.Name, entry.Value)
+		}
+
+		attrs = append(attrs, slog.Group(l.Name, values...))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue returns the Report for the Configuration for use with slog.
+func (c *Configuration) LogValue() slog.Value {
+	return c.Report().LogValue()
+}
+
+// Log the Report. The first Report is logged as "configuration". Subsequent
+// Reports are logged as "configuration changed" with the old and new value of
+// each changed Setting, and are not logged if nothing has changed.
+func (l *ReportLogger) Log(ctx context.Context, report Report) {
+	if l.last == nil {
+		l.Logger.LogAttrs(ctx, l.Level, "configuration", slog.Any("config", report))
+	} else if changes := l.last.changes(report); len(changes) > 0 {
+		l.Logger.LogAttrs(ctx, l.Level, "configuration changed",
+			slog.Any("changes", slog.GroupValue(changes...)))
+	}
+
+	l.last = report
+}
+
+// changes from this Report to the next, grouped by Line. Each changed Setting
+// is a group holding the old and new values, with a missing value omitted.
+func (r Report) changes(next Report) []slog.Attr {
+	var attrs []slog.Attr
+
+	for _, name := range union(next.names(), r.names()) {
+		var changed []any
+
+		previous, current := r.line(name), next.line(name)
+
+		for _, setting := range union(current.names(), previous.names()) {
+			from, had := previous.value(setting)
+			to, has := current.value(setting)
+
+			if had == has && from == to {
+				continue
+			}
+
+			var values []any
+
+			if had {
+				values = append(values, slog.String("from", from))
+			}
+
+			if has {
+				values = append(values, slog.String("to", to))
+			}
+
+			changed = append(changed, slog.Group(setting, values...))
+		}
+
+		if len(changed) > 0 {
+			attrs = append(attrs, slog.Group(name, changed...))
+		}
+	}
+
+	return attrs
+}
+
+// names of the Lines in the Report.
+func (r Report) names() []string {
+	names := make([]string, len(r))
+
+	for i, l := range r {
+		names[i] = l.Name
+	}
+
+	return names
+}
+
+// line with the given name, or an empty Line if there isn't one.
+func (r Report) line(name string) Line {
+	for _, l := range r {
+		if l.Name == name {
+			return l
+		}
+	}
+
+	return Line{Name: name}
+}
+
+// names of the Entries in the Line.
+func (l Line) names() []string {
+	names := make([]string, len(l.Entries))
+
+	for i, entry := range l.Entries {
+		names[i] = entry.Name
+	}
+
+	return names
+}
+
+// value of the named Entry, and whether the Line has it.
+func (l Line) value(name string) (string, bool) {
+	for _, entry := range l.Entries {
+		if entry.Name == name {
+			return entry.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// union of the names, in order, without duplicates.
+func union(names ...[]string) []string {
+	var result []string
+
+	seen := map[string]bool{}
+
+	for _, list := range names {
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				result = append(result, name)
+			}
+		}
+	}
+
+	return result
+}
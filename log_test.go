@@ -0,0 +1,76 @@
+package gofigure_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func configure(args ...string) *gofigure.Configuration {
+	var host, password string
+
+	var port int
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	group := config.Group("db")
+	group.Add(gofigure.Optional("Host", "host", &host, "localhost",
+		gofigure.NamedSources, gofigure.ReportValue, "Database host"))
+	group.Add(gofigure.Optional("Port", "port", &port, 5432,
+		gofigure.NamedSources, gofigure.ReportValue, "Database port"))
+	group.Add(gofigure.Optional("Password", "password", &password, "",
+		gofigure.NamedSources, gofigure.MaskValue, "Database password"))
+
+	if err := config.ParseUsing(args); err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	return config
+}
+
+func ExampleReportLogger() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+
+	reporter := gofigure.NewReportLogger(logger)
+	reporter.Log(context.Background(), configure("--password", "secret").Report())
+	reporter.Log(context.Background(), configure("--password", "secret").Report())
+	reporter.Log(context.Background(), configure("--password", "secret", "--port", "6543").Report())
+
+	// Output:
+	// level=INFO msg=configuration config.db.Host=localhost config.db.Port=5432 config.db.Password=SET
+	// level=INFO msg="configuration changed" changes.db.Port.from=5432 changes.db.Port.to=6543
+}
+
+func TestReport_LogValue(t *testing.T) {
+	t.Run("Configurations log their report", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewJSONHandler(buf, nil))
+
+		logger.Info("starting", "config", configure("--password", "secret"))
+
+		assert.Contains(t, buf.String(),
+			`"config":{"db":{"Host":"localhost","Port":"5432","Password":"SET"}}`)
+		assert.NotContains(t, buf.String(), "secret")
+	})
+
+	t.Run("Nil loggers use the default logger", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, slog.Default(), gofigure.NewReportLogger(nil).Logger)
+	})
+}
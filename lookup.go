@@ -0,0 +1,84 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownSetting is returned by Lookup if no Setting has the given name.
+var ErrUnknownSetting = errors.New("unknown setting")
+
+// ErrTypeMismatch is returned by GetValue if the Value is not of the requested
+// type.
+var ErrTypeMismatch = errors.New("type mismatch")
+
+// Lookup the current value of a Setting by name, returning the value and the
+// Source it was set from. The name can be the name a Setting's Parameters were
+// created with (i.e. its Flag, Key, or Credential name), or the name of its
+// Value. Settings defined on the Configuration and the selected Command are
+// searched in declaration order. Settings can be declared without a variable to
+// hold their value by passing a nil pointer to Optional or Required, and then
+// read using Lookup or GetValue. ErrUnknownSetting is returned if no Setting
+// matches the name.
+func (c *Configuration) Lookup(name string) (any, Source, error) {
+	setting := c.setting(name)
+
+	if setting == nil {
+		return nil, None, fmt.Errorf("%w: %s", ErrUnknownSetting, name)
+	}
+
+	return Dereference(setting.Value.Ptr), setting.Value.Source, nil
+}
+
+// GetValue returns the current value of the named Setting as the given type.
+// The name is resolved as for Lookup. ErrTypeMismatch is returned if the value
+// is not of type T.
+func GetValue[T any](c *Configuration, name string) (T, error) {
+	var zero T
+
+	value, _, err := c.Lookup(name)
+
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := value.(T)
+
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is %T, not %T", ErrTypeMismatch, name,
+			value, zero)
+	}
+
+	return typed, nil
+}
+
+// setting returns the named Setting, or nil if there isn't one.
+func (c *Configuration) setting(name string) *Setting {
+	for _, scope := range c.scopes(c.Selected) {
+		for _, group := range scope.groups {
+			for _, setting := range group.Settings {
+				if setting.named(name) {
+					return setting
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// named returns true if the Setting has the given name.
+func (s Setting) named(name string) bool {
+	if s.Value.Name == name {
+		return true
+	}
+
+	for _, parameter := range s.Parameters {
+		if parameter.Source != EnvVar && parameter.Source != ShortFlag &&
+			parameter.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleGetValue() {
+	config := gofigure.NewConfiguration("EXAMPLE")
+	group := config.Group("database")
+	group.Add(gofigure.Optional[time.Duration]("Timeout", "timeout", nil,
+		time.Second, gofigure.NamedSources, gofigure.ReportValue, "Query timeout"))
+	group.Add(gofigure.Required[string]("Host", "db.host", nil,
+		gofigure.NamedSources, gofigure.ReportValue, "Database host"))
+
+	if err := config.ParseUsing([]string{"--db.host", "localhost"}); err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	timeout, err := gofigure.GetValue[time.Duration](config, "timeout")
+	fmt.Println(timeout, err)
+
+	host, source, err := config.Lookup("db.host")
+	fmt.Println(host, source, err)
+
+	// Output:
+	// 1s <nil>
+	// localhost flag <nil>
+}
+
+func TestGetValue(t *testing.T) {
+	t.Run("Unknown settings return an error", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+
+		_, err := gofigure.GetValue[string](config, "missing")
+		assert.ErrorIs(t, err, gofigure.ErrUnknownSetting)
+	})
+
+	t.Run("Type mismatches return an error", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+		config.Group("test").Add(gofigure.Optional[int]("Count", "count", nil, 3,
+			gofigure.NamedSources, gofigure.ReportValue, "Count"))
+
+		_, err := gofigure.GetValue[string](config, "count")
+		assert.ErrorIs(t, err, gofigure.ErrTypeMismatch)
+
+		count, err := gofigure.GetValue[int](config, "Count")
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("Pointers given to settings are still used", func(t *testing.T) {
+		t.Parallel()
+
+		var name string
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+		config.Group("test").Add(gofigure.Optional("Name", "name", &name, "a",
+			gofigure.NamedSources, gofigure.ReportValue, "Name"))
+
+		assert.NoError(t, config.ParseUsing([]string{"--name", "b"}))
+
+		value, source, err := config.Lookup("name")
+		assert.NoError(t, err)
+		assert.Equal(t, "b", value)
+		assert.Equal(t, "b", name)
+		assert.Equal(t, gofigure.Flag, source)
+	})
+}
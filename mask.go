@@ -22,6 +22,10 @@ const (
 	// Set rather than NotSet.
 	DefaultIsSet = Mask(1 << iota)
 
+	// RevealCredential will report values set via a Credential using the rest
+	// of the Mask, rather than always masking them.
+	RevealCredential = Mask(1 << iota)
+
 	// ReportValue will report the definition value.
 	ReportValue = 0
 
@@ -41,6 +45,9 @@ const (
 
 	// Invalid is used when a Value is invalid.
 	Invalid = "INVALID"
+
+	// Redacted is used in place of the value of a Secret.
+	Redacted = "REDACTED"
 )
 
 // Contains returns true if the Mask contains the given Mask.
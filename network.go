@@ -0,0 +1,88 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HostPort is a network address in the form host:port. If a value is given
+// without a port then the port from the default value of the Setting is used.
+type HostPort string
+
+// ErrInvalidAddress is returned if a HostPort cannot be parsed.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ParseHostPort parses a host:port address. The port must be a number.
+func ParseHostPort(s string) (HostPort, error) {
+	host, port, err := net.SplitHostPort(s)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidAddress, err)
+	}
+
+	if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("%w: invalid port %q", ErrInvalidAddress, port)
+	}
+
+	return HostPort(net.JoinHostPort(host, port)), nil
+}
+
+// Host returns the host part of the address.
+func (h HostPort) Host() string {
+	host, _, _ := net.SplitHostPort(string(h))
+
+	return host
+}
+
+// Port returns the port part of the address.
+func (h HostPort) Port() uint16 {
+	_, port, _ := net.SplitHostPort(string(h))
+	p, _ := strconv.ParseUint(port, 10, 16)
+
+	return uint16(p)
+}
+
+// Schemes returns a Validator that requires a URL to use one of the given
+// schemes. A nil URL, such as the default for an optional URL, is accepted.
+func Schemes(schemes ...string) Validator {
+	return Validator{
+		Description: fmt.Sprintf("scheme: %s", strings.Join(schemes, ", ")),
+		check: func(value any) error {
+			u, ok := value.(*url.URL)
+
+			if ok && u == nil {
+				return nil
+			}
+
+			for _, scheme := range schemes {
+				if ok && strings.EqualFold(u.Scheme, scheme) {
+					return nil
+				}
+			}
+
+			return validationError(fmt.Sprintf("must use scheme: %s",
+				strings.Join(schemes, ", ")))
+		},
+	}
+}
+
+// hostPort adds the port from the Value's default to string values without a
+// port. Other values are returned as is.
+func (v *Value) hostPort(value any) any {
+	s, ok := value.(string)
+	base, hasBase := v.base.(HostPort)
+
+	if !ok || !hasBase || base.Port() == 0 {
+		return value
+	}
+
+	if _, _, err := net.SplitHostPort(s); err == nil {
+		return value
+	}
+
+	return net.JoinHostPort(strings.Trim(s, "[]"), strconv.Itoa(int(base.Port())))
+}
@@ -0,0 +1,121 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleHostPort() {
+	setup := func() (*gofigure.Configuration, *gofigure.HostPort) {
+		var listen gofigure.HostPort
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+		config.Group("server").Add(gofigure.Optional("Listen", "listen", &listen,
+			"0.0.0.0:8080", gofigure.Flag, gofigure.ReportValue, "Listen address"))
+
+		return config, &listen
+	}
+
+	for _, args := range [][]string{{}, {"--listen", "localhost"}, {"--listen", "::1"},
+		{"--listen", "localhost:9000"}, {"--listen", "localhost:http"}} {
+		config, listen := setup()
+
+		if err := config.ParseUsing(args); err != nil {
+			fmt.Println(config.Format(err))
+		} else {
+			fmt.Println(*listen, listen.Host(), listen.Port())
+		}
+	}
+
+	config, _ := setup()
+	fmt.Print(config.Usage())
+
+	// Output:
+	// 0.0.0.0:8080 0.0.0.0 8080
+	// localhost:8080 localhost 8080
+	// [::1]:8080 ::1 8080
+	// localhost:9000 localhost 9000
+	// invalid value 'localhost:http': [--listen]
+	// usage:
+	//   Listen [--listen]
+	//     Listen address (host:port; default: 0.0.0.0:8080)
+}
+
+func TestSchemes(t *testing.T) {
+	t.Run("URLs must use an allowed scheme", func(t *testing.T) {
+		t.Parallel()
+
+		var upstream *url.URL
+
+		setup := func() *gofigure.Configuration {
+			config := gofigure.NewConfiguration("")
+			config.Group("upstream").Add(gofigure.Required("Upstream", "upstream",
+				&upstream, gofigure.Flag, gofigure.ReportValue, "Upstream API").
+				Check(gofigure.Schemes("http", "https")))
+
+			return config
+		}
+
+		assert.NoError(t, setup().ParseUsing([]string{"--upstream", "https://example.com/api"}))
+		assert.Equal(t, "example.com", upstream.Host)
+
+		config := setup()
+		err := config.ParseUsing([]string{"--upstream", "ftp://example.com"})
+		assert.ErrorIs(t, err, gofigure.ErrFailedValidation)
+		assert.Equal(t, "invalid value 'ftp://example.com' (must use scheme: http, https): "+
+			"[--upstream]", config.Format(err))
+		assert.Contains(t, config.Usage(), "Upstream API (URL; required; scheme: http, https)")
+	})
+
+	t.Run("Optional URLs can be left unset", func(t *testing.T) {
+		t.Parallel()
+
+		var proxy *url.URL
+
+		config := gofigure.NewConfiguration("")
+		config.Group("proxy").Add(gofigure.Optional("Proxy", "proxy", &proxy, nil,
+			gofigure.Flag, gofigure.ReportValue, "Proxy").Check(gofigure.Schemes("https")))
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Nil(t, proxy)
+		assert.Contains(t, config.Usage(), "Proxy (URL; scheme: https)")
+	})
+}
+
+func TestValue_Assign_Network(t *testing.T) {
+	t.Run("Addresses and prefixes are parsed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			addr   netip.Addr
+			prefix netip.Prefix
+		)
+
+		addrValue := gofigure.NewValue("addr", &addr, netip.Addr{}, gofigure.None, "addr")
+		prefixValue := gofigure.NewValue("prefix", &prefix, netip.Prefix{}, gofigure.None, "prefix")
+
+		assert.NoError(t, addrValue.Assign("192.168.0.1", gofigure.Flag))
+		assert.NoError(t, prefixValue.Assign("10.0.0.0/8", gofigure.Flag))
+		assert.True(t, prefix.Contains(netip.MustParseAddr("10.1.2.3")))
+		assert.Equal(t, "192.168.0.1", addr.String())
+
+		assert.Error(t, addrValue.Assign("192.168.0.256", gofigure.Flag))
+		assert.Error(t, prefixValue.Assign("10.0.0.0", gofigure.Flag))
+	})
+
+	t.Run("Host and port are required without a default", func(t *testing.T) {
+		t.Parallel()
+
+		var address gofigure.HostPort
+
+		value := gofigure.NewValue("address", &address, "", gofigure.None, "address")
+
+		assert.ErrorIs(t, value.Assign("localhost", gofigure.Flag), gofigure.ErrInvalidAddress)
+		assert.NoError(t, value.Assign("localhost:80", gofigure.Flag))
+	})
+}
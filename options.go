@@ -10,10 +10,21 @@ import (
 type Options map[Parameter]any
 
 func (o Options) String() string {
+	keys := o.sorted()
+	values := make([]string, len(o))
+
+	for i, key := range keys {
+		values[i] = fmt.Sprintf("%s:%v", key.FullName(), o[key])
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(values, ", "))
+}
+
+// sorted returns the Parameters in the Options, sorted by their full name.
+func (o Options) sorted() []Parameter {
 	var i int
 
 	keys := make([]Parameter, len(o))
-	values := make([]string, len(o))
 
 	for k := range o {
 		keys[i] = k
@@ -24,9 +35,5 @@ func (o Options) String() string {
 		return keys[i].FullName() < keys[j].FullName()
 	})
 
-	for i, key := range keys {
-		values[i] = fmt.Sprintf("%s:%v", key.FullName(), o[key])
-	}
-
-	return fmt.Sprintf("[%s]", strings.Join(values, ", "))
+	return keys
 }
@@ -18,9 +18,9 @@ type Parameters []Parameter
 
 // NewParameters returns a set of named parameters for the given sources.
 // Combine multiple sources with | (e.g. Flag | EnvVar). The given name is used
-// for each source with Flag and Key using the name as is, EnvSuffix set to the
-// uppercase version of the name, and ShortFlag set to the first character of
-// name.
+// for each source with Flag, Key, and Credential using the name as is,
+// EnvSuffix set to the uppercase version of the name, and ShortFlag set to the
+// first character of name.
 func NewParameters(name string, sources Source) Parameters {
 	var p []Parameter
 
@@ -36,6 +36,10 @@ func NewParameters(name string, sources Source) Parameters {
 		p = append(p, Parameter{Name: strings.ToUpper(name), Source: EnvVar})
 	}
 
+	if sources.Contains(Credential) {
+		p = append(p, Parameter{Name: name, Source: Credential})
+	}
+
 	if sources.Contains(ShortFlag) {
 		p = append(p, Parameter{Name: string(name[0]), Source: ShortFlag})
 	}
@@ -71,6 +75,8 @@ func (p Parameter) String() string {
 		return "-" + p.Name
 	case EnvVar:
 		return fmt.Sprintf("env %s", p.FullName())
+	case Credential:
+		return fmt.Sprintf("credential %s", p.Name)
 	case Key:
 		return fmt.Sprintf("JSON key: %q", p.Name)
 	case configFile:
@@ -85,12 +91,16 @@ func (p Parameter) Matches(parameter Parameter) bool {
 	return p.Name == parameter.Name && p.Source.Contains(parameter.Source)
 }
 
-// Format the given Parameters into a human-readable string.
+// Format the given Parameters into a human-readable string. The prefix is
+// used as the Stub for any Parameter that doesn't already have one.
 func (p Parameters) Format(prefix string) string {
 	parameters := make([]string, len(p))
 
 	for i, parameter := range p {
-		parameter.Stub = prefix
+		if parameter.Stub == "" {
+			parameter.Stub = prefix
+		}
+
 		parameters[i] = parameter.String()
 	}
 
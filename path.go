@@ -0,0 +1,144 @@
+package gofigure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Path to a file or directory. A leading ~ is expanded to the user's home
+// directory. Paths can be checked using the Exists, IsFile, IsDir, Readable,
+// Writable, and MaxPermissions Validators.
+type Path string
+
+// ExpandPath expands a leading ~ in the path to the user's home directory.
+func ExpandPath(path string) (Path, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return Path(path), nil
+	}
+
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return Path(path), fmt.Errorf("cannot expand %q: %w", path, err)
+	}
+
+	return Path(filepath.Join(home, strings.TrimPrefix(path, "~"))), nil
+}
+
+// Exists returns a Validator that requires a path to exist.
+func Exists() Validator {
+	return pathValidator("existing path", func(path string, _ os.FileInfo) error {
+		return nil
+	})
+}
+
+// IsFile returns a Validator that requires a path to be an existing file.
+func IsFile() Validator {
+	return pathValidator("existing file", func(path string, info os.FileInfo) error {
+		if info.IsDir() {
+			return validationError("must be a file")
+		}
+
+		return nil
+	})
+}
+
+// IsDir returns a Validator that requires a path to be an existing directory.
+// Shell completion for the Setting will complete directories.
+func IsDir() Validator {
+	v := pathValidator("existing directory", func(path string, info os.FileInfo) error {
+		if !info.IsDir() {
+			return validationError("must be a directory")
+		}
+
+		return nil
+	})
+	v.directory = true
+
+	return v
+}
+
+// Readable returns a Validator that requires a path to be readable.
+func Readable() Validator {
+	return pathValidator("readable", func(path string, _ os.FileInfo) error {
+		f, err := os.Open(path) //nolint:gosec // Opening the file is the point.
+
+		if err != nil {
+			return validationError("must be readable")
+		}
+
+		_ = f.Close()
+
+		return nil
+	})
+}
+
+// Writable returns a Validator that requires a path to be writable. Files are
+// opened for writing without truncation, and directories are checked by
+// creating and removing a temporary file.
+func Writable() Validator {
+	return pathValidator("writable", func(path string, info os.FileInfo) error {
+		var (
+			f   *os.File
+			err error
+		)
+
+		if info.IsDir() {
+			f, err = os.CreateTemp(path, ".gofigure-*")
+		} else {
+			f, err = os.OpenFile(path, os.O_WRONLY, 0) //nolint:gosec // Opening the file is the point.
+		}
+
+		if err != nil {
+			return validationError("must be writable")
+		}
+
+		_ = f.Close()
+
+		if info.IsDir() {
+			_ = os.Remove(f.Name())
+		}
+
+		return nil
+	})
+}
+
+// MaxPermissions returns a Validator that requires a path to have no
+// permissions beyond those given (e.g. 0o600 for a private key).
+func MaxPermissions(perm os.FileMode) Validator {
+	return pathValidator(fmt.Sprintf("permissions at most %#o", perm),
+		func(path string, info os.FileInfo) error {
+			if info.Mode().Perm()&^perm != 0 {
+				return validationError(fmt.Sprintf("must have permissions at most %#o, has %#o",
+					perm, info.Mode().Perm()))
+			}
+
+			return nil
+		})
+}
+
+// pathValidator returns a Validator that checks the path exists before calling
+// the check function.
+func pathValidator(description string, f func(path string, info os.FileInfo) error) Validator {
+	return Validator{
+		Description: description,
+		check: func(value any) error {
+			v := reflect.ValueOf(value)
+
+			if v.Kind() != reflect.String {
+				return fmt.Errorf("%w: expected a path, got %T", ErrInvalidType, value)
+			}
+
+			info, err := os.Stat(v.String())
+
+			if err != nil {
+				return validationError("must exist")
+			}
+
+			return f(v.String(), info)
+		},
+	}
+}
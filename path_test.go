@@ -0,0 +1,91 @@
+package gofigure_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPath(t *testing.T) {
+	t.Run("Home directories are expanded", func(t *testing.T) {
+		t.Parallel()
+
+		home, err := os.UserHomeDir()
+		assert.NoError(t, err)
+
+		path, err := gofigure.ExpandPath("~/config.json")
+		assert.NoError(t, err)
+		assert.Equal(t, gofigure.Path(filepath.Join(home, "config.json")), path)
+
+		path, err = gofigure.ExpandPath("~other/config.json")
+		assert.NoError(t, err)
+		assert.Equal(t, gofigure.Path("~other/config.json"), path)
+	})
+
+	t.Run("Defaults are expanded", func(t *testing.T) {
+		t.Parallel()
+
+		var path gofigure.Path
+
+		home, err := os.UserHomeDir()
+		assert.NoError(t, err)
+
+		config := gofigure.NewConfiguration("")
+		config.Group("paths").Add(gofigure.Optional("Home", "home", &path,
+			"~", gofigure.Flag, gofigure.ReportValue, "home").Check(gofigure.IsDir()))
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Equal(t, gofigure.Path(home), path)
+	})
+}
+
+func TestPathValidators(t *testing.T) {
+	t.Run("Paths are checked", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		file := filepath.Join(dir, "key")
+		assert.NoError(t, os.WriteFile(file, []byte("key"), 0o644))
+
+		assert.NoError(t, gofigure.Exists().Validate(gofigure.Path(dir)))
+		assert.NoError(t, gofigure.IsDir().Validate(gofigure.Path(dir)))
+		assert.NoError(t, gofigure.IsFile().Validate(gofigure.Path(file)))
+		assert.NoError(t, gofigure.Readable().Validate(gofigure.Path(file)))
+		assert.NoError(t, gofigure.Writable().Validate(gofigure.Path(file)))
+		assert.NoError(t, gofigure.Writable().Validate(gofigure.Path(dir)))
+		assert.NoError(t, gofigure.MaxPermissions(0o644).Validate(gofigure.Path(file)))
+
+		assert.ErrorIs(t, gofigure.IsFile().Validate(gofigure.Path(dir)),
+			gofigure.ErrFailedValidation)
+		assert.ErrorIs(t, gofigure.IsDir().Validate(gofigure.Path(file)),
+			gofigure.ErrFailedValidation)
+		assert.ErrorIs(t, gofigure.Exists().Validate(gofigure.Path(filepath.Join(dir, "missing"))),
+			gofigure.ErrFailedValidation)
+		assert.ErrorIs(t, gofigure.MaxPermissions(0o600).Validate(gofigure.Path(file)),
+			gofigure.ErrFailedValidation)
+		assert.ErrorIs(t, gofigure.Exists().Validate(1), gofigure.ErrInvalidType)
+
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("Directories complete as directories", func(t *testing.T) {
+		t.Parallel()
+
+		var dir gofigure.Path
+
+		config := gofigure.NewConfiguration("")
+		config.Group("paths").Add(gofigure.Optional("Data", "data", &dir, ".",
+			gofigure.Flag, gofigure.ReportValue, "Data directory").Check(gofigure.IsDir()))
+
+		script, err := config.CompletionScript(gofigure.Bash, "tool")
+		assert.NoError(t, err)
+		assert.True(t, strings.Contains(script, "compgen -d"))
+		assert.Contains(t, config.Usage(), "Data directory (path; default: .; existing directory)")
+	})
+}
@@ -0,0 +1,162 @@
+package gofigure
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reference section for a single level of the Command tree. The root section
+// has no Command.
+type reference struct {
+	command *Command
+	groups  []*Group
+	prefix  string
+}
+
+// Man page for the Configuration in roff man(1) format. The program name and
+// description are used in the NAME section, and the Build string is used as
+// the page footer.
+func (c *Configuration) Man(program, description string) string {
+	b := strings.Builder{}
+
+	fmt.Fprintf(&b, ".TH %s 1 \"\" \"%s\" \"%s\"\n", roff(strings.ToUpper(program)),
+		roff(Build()), roff(program))
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", roff(program), roff(description))
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, ".B %s\n", roff(program))
+
+	if len(c.Commands) > 0 {
+		b.WriteString("[\\fIcommand\\fR] ")
+	}
+
+	b.WriteString("[\\fIoptions\\fR]\n")
+
+	for _, ref := range c.references(nil) {
+		if ref.command == nil {
+			b.WriteString(".SH OPTIONS\n")
+		} else {
+			fmt.Fprintf(&b, ".SH COMMAND: %s\n", roff(strings.ToUpper(ref.command.String())))
+			fmt.Fprintf(&b, "%s\n", roff(ref.command.Description))
+		}
+
+		for _, group := range ref.groups {
+			if !documented(group) {
+				continue
+			}
+
+			fmt.Fprintf(&b, ".SS %s\n", roff(group.Name))
+
+			for _, setting := range group.Settings {
+				if len(setting.Parameters) == 0 {
+					continue
+				}
+
+				b.WriteString(".TP\n")
+				fmt.Fprintf(&b, "\\fB%s\\fR %s\n", roff(setting.Value.Name),
+					roff(setting.Parameters.Format(ref.prefix)))
+				b.WriteString(roff(setting.Value.Description))
+
+				if notes := setting.notes(); len(notes) > 0 {
+					fmt.Fprintf(&b, " (%s)", roff(strings.Join(notes, "; ")))
+				}
+
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	b.WriteString(".SH VERSION\n")
+	fmt.Fprintf(&b, "%s\n", roff(Build()))
+
+	return b.String()
+}
+
+// Markdown reference for the Configuration. Each Group is rendered as a table
+// of Settings, with Commands given their own sections.
+func (c *Configuration) Markdown(program, description string) string {
+	b := strings.Builder{}
+
+	fmt.Fprintf(&b, "# %s\n\n%s\n\nVersion: `%s`\n", program, description, Build())
+
+	for _, ref := range c.references(nil) {
+		level := "##"
+
+		if ref.command != nil {
+			level = "###"
+			fmt.Fprintf(&b, "\n## Command: `%s`\n\n%s\n", ref.command.String(),
+				markdown(ref.command.Description))
+		}
+
+		for _, group := range ref.groups {
+			if !documented(group) {
+				continue
+			}
+
+			fmt.Fprintf(&b, "\n%s %s\n\n", level, group.Name)
+			b.WriteString("| Setting | Parameters | Description | Notes |\n")
+			b.WriteString("| --- | --- | --- | --- |\n")
+
+			for _, setting := range group.Settings {
+				if len(setting.Parameters) == 0 {
+					continue
+				}
+
+				fmt.Fprintf(&b, "| %s | `%s` | %s | %s |\n",
+					markdown(setting.Value.Name),
+					markdown(setting.Parameters.Format(ref.prefix)),
+					markdown(setting.Value.Description),
+					markdown(strings.Join(setting.notes(), "; ")))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// references for the given Command and all Commands below it. Each reference
+// only holds the Groups defined at that level of the Command tree.
+func (c *Configuration) references(cmd *Command) []reference {
+	scopes := c.scopes(cmd)
+	refs := []reference{{command: cmd, groups: scopes[len(scopes)-1].groups,
+		prefix: scopes[len(scopes)-1].prefix}}
+	commands := c.Commands
+
+	if cmd != nil {
+		commands = cmd.Commands
+	}
+
+	for _, sub := range commands {
+		refs = append(refs, c.references(sub)...)
+	}
+
+	return refs
+}
+
+// documented returns true if the Group has any Settings that can be set.
+func documented(group *Group) bool {
+	for _, setting := range group.Settings {
+		if len(setting.Parameters) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// roff escapes a string for use in a man page.
+func roff(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+
+	return s
+}
+
+// markdown escapes a string for use in a Markdown table.
+func markdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,112 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleConfiguration_Markdown() {
+	var (
+		name    string
+		timeout int
+		steps   int
+	)
+
+	config := gofigure.NewConfiguration("TOOL")
+	config.AddHelp(gofigure.CommandLine)
+
+	group := config.Group("settings")
+	group.Add(gofigure.Required("Name", "name", &name, gofigure.NamedSources,
+		gofigure.ReportValue, "Application name"))
+	group.Add(gofigure.Optional("Timeout", "timeout", &timeout, 30,
+		gofigure.Flag|gofigure.EnvVar, gofigure.ReportValue, "Timeout | seconds"))
+
+	config.Command("migrate", "Run migrations").Group("migration").Add(
+		gofigure.Optional("Steps", "steps", &steps, 1, gofigure.Flag,
+			gofigure.ReportValue, "Steps to migrate"))
+
+	fmt.Print(config.Markdown("tool", "An example tool."))
+
+	// Output:
+	// # tool
+	//
+	// An example tool.
+	//
+	// Version: `dev [<unset>] (built: <unset>)`
+	//
+	// ## Base Configuration
+	//
+	// | Setting | Parameters | Description | Notes |
+	// | --- | --- | --- | --- |
+	// | Help | `[-h, --help]` | Display usage information |  |
+	//
+	// ## settings
+	//
+	// | Setting | Parameters | Description | Notes |
+	// | --- | --- | --- | --- |
+	// | Name | `[JSON key: "name", env TOOL_NAME, --name]` | Application name | required |
+	// | Timeout | `[env TOOL_TIMEOUT, --timeout]` | Timeout \| seconds | default: 30 |
+	//
+	// ## Command: `migrate`
+	//
+	// Run migrations
+	//
+	// ### migration
+	//
+	// | Setting | Parameters | Description | Notes |
+	// | --- | --- | --- | --- |
+	// | Steps | `[--steps]` | Steps to migrate | default: 1 |
+}
+
+func TestConfiguration_Man(t *testing.T) {
+	t.Run("Settings are rendered as roff", func(t *testing.T) {
+		t.Parallel()
+
+		var secret, name string
+
+		config := gofigure.NewConfiguration("TOOL")
+		config.Group("settings").Add(gofigure.Optional("Secret", "secret",
+			&secret, "hidden", gofigure.Flag, gofigure.HideUnset, "A secret"))
+		config.Group("settings").Add(gofigure.Required("Name", "name",
+			&name, gofigure.EnvVar, gofigure.ReportValue, ".name"))
+
+		man := config.Man("tool", "an example tool")
+
+		assert.Equal(t, ".TH TOOL 1 \"\" \"dev [<unset>] (built: <unset>)\" \"tool\"\n"+
+			".SH NAME\n"+
+			"tool \\- an example tool\n"+
+			".SH SYNOPSIS\n"+
+			".B tool\n"+
+			"[\\fIoptions\\fR]\n"+
+			".SH OPTIONS\n"+
+			".SS settings\n"+
+			".TP\n"+
+			"\\fBSecret\\fR [\\-\\-secret]\n"+
+			"A secret\n"+
+			".TP\n"+
+			"\\fBName\\fR [env TOOL_NAME]\n"+
+			"\\&.name (required)\n"+
+			".SH VERSION\n"+
+			"dev [<unset>] (built: <unset>)\n", man)
+	})
+
+	t.Run("Commands are given their own sections", func(t *testing.T) {
+		t.Parallel()
+
+		var value string
+
+		config := gofigure.NewConfiguration("")
+		config.Command("serve", "Serve things").Group("server").Add(
+			gofigure.Optional("Value", "value", &value, "x", gofigure.Flag,
+				gofigure.ReportValue, "A value"))
+
+		man := config.Man("tool", "an example tool")
+
+		assert.Contains(t, man, "[\\fIcommand\\fR] [\\fIoptions\\fR]\n")
+		assert.Contains(t, man, ".SH COMMAND: SERVE\nServe things\n.SS server\n")
+		assert.Contains(t, man, "A value (default: x)\n")
+	})
+}
@@ -0,0 +1,205 @@
+package gofigure
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Entry in a Line of a Report, holding the display value of a Setting along
+// with the Source and Provenance of the Value. The Value will respect the Mask
+// on the Setting.
+type Entry struct {
+	Name       string
+	Value      string
+	Source     Source
+	Provenance string
+}
+
+// Detail to include when rendering a Report in addition to the name and value
+// of each Setting. Combine multiple details with | (e.g. WithSource |
+// WithProvenance).
+type Detail uint8
+
+type entry struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	Source     string `json:"source,omitempty"`
+	Provenance string `json:"provenance,omitempty"`
+}
+
+type line struct {
+	Group    string  `json:"group"`
+	Settings []entry `json:"settings"`
+}
+
+// yamlPlain matches strings that can be written in YAML without quotes.
+//
+//nolint:gochecknoglobals // Compiled once.
+var yamlPlain = regexp.MustCompile(`^[A-Za-z_/.][A-Za-z0-9_./@-]*( [A-Za-z0-9_./@-]+)*$`)
+
+const (
+	// WithSource includes the Source of each Value.
+	WithSource = Detail(1 << iota)
+
+	// WithProvenance includes the Provenance of each Value, if it has one.
+	WithProvenance = Detail(1 << iota)
+)
+
+// Contains returns true if the Detail contains the given Detail.
+func (d Detail) Contains(detail Detail) bool {
+	return d&detail != 0
+}
+
+// Text renders the Report as a set of aligned tables, one per Line, with
+// Settings in the order they were declared.
+func (r Report) Text(detail Detail) string {
+	b := strings.Builder{}
+
+	for i, l := range r.lines(detail) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		b.WriteString(l.Group)
+		b.WriteString(":\n")
+
+		w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+		for _, e := range l.Settings {
+			cells := []string{"", e.Name, e.Value, e.Source, e.Provenance}
+
+			for cells[len(cells)-1] == "" {
+				cells = cells[:len(cells)-1]
+			}
+
+			_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+		}
+
+		_ = w.Flush()
+	}
+
+	return b.String()
+}
+
+// JSON renders the Report as a JSON array of groups, each holding an array of
+// Settings in the order they were declared.
+func (r Report) JSON(detail Detail) ([]byte, error) {
+	b, err := json.MarshalIndent(r.lines(detail), "", "  ")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+
+	return b, nil
+}
+
+// YAML renders the Report as a YAML document mapping each group to its
+// Settings in the order they were declared. If any Detail is requested each
+// Setting is rendered as a mapping holding the value and the details,
+// otherwise Settings map directly to their values.
+func (r Report) YAML(detail Detail) string {
+	b := strings.Builder{}
+
+	for _, l := range r.lines(detail) {
+		b.WriteString(fmt.Sprintf("%s:\n", yaml(l.Group)))
+
+		for _, e := range l.Settings {
+			if detail == 0 {
+				b.WriteString(fmt.Sprintf("  %s: %s\n", yaml(e.Name), yaml(e.Value)))
+
+				continue
+			}
+
+			b.WriteString(fmt.Sprintf("  %s:\n", yaml(e.Name)))
+			b.WriteString(fmt.Sprintf("    value: %s\n", yaml(e.Value)))
+
+			if e.Source != "" {
+				b.WriteString(fmt.Sprintf("    source: %s\n", yaml(e.Source)))
+			}
+
+			if e.Provenance != "" {
+				b.WriteString(fmt.Sprintf("    provenance: %s\n", yaml(e.Provenance)))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// Logfmt renders the Report in logfmt, with one line per Setting in the order
+// they were declared.
+func (r Report) Logfmt(detail Detail) string {
+	b := strings.Builder{}
+
+	for _, l := range r.lines(detail) {
+		for _, e := range l.Settings {
+			b.WriteString(fmt.Sprintf("group=%s setting=%s value=%s", logfmt(l.Group),
+				logfmt(e.Name), logfmt(e.Value)))
+
+			if e.Source != "" {
+				b.WriteString(fmt.Sprintf(" source=%s", logfmt(e.Source)))
+			}
+
+			if e.Provenance != "" {
+				b.WriteString(fmt.Sprintf(" provenance=%s", logfmt(e.Provenance)))
+			}
+
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// lines in the Report, holding only the requested details.
+func (r Report) lines(detail Detail) []line {
+	lines := make([]line, len(r))
+
+	for i, l := range r {
+		lines[i] = line{Group: l.Name, Settings: make([]entry, len(l.Entries))}
+
+		for j, e := range l.Entries {
+			lines[i].Settings[j] = entry{Name: e.Name, Value: e.Value}
+
+			if detail.Contains(WithSource) {
+				lines[i].Settings[j].Source = e.Source.String()
+			}
+
+			if detail.Contains(WithProvenance) {
+				lines[i].Settings[j].Provenance = e.Provenance
+			}
+		}
+	}
+
+	return lines
+}
+
+// yaml quotes the string if it can't be written as a plain YAML scalar. JSON
+// strings are valid YAML so are used for quoting.
+func yaml(s string) string {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+	default:
+		if yamlPlain.MatchString(s) {
+			return s
+		}
+	}
+
+	b, _ := json.Marshal(s)
+
+	return string(b)
+}
+
+// logfmt quotes the string if it is empty or contains spaces, quotes, or
+// equals signs.
+func logfmt(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
@@ -0,0 +1,112 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func report(t *testing.T) gofigure.Report {
+	t.Helper()
+
+	var host, password, name string
+
+	var port int
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	db := config.Group("database")
+	db.Add(gofigure.Optional("Host", "host", &host, "localhost",
+		gofigure.NamedSources, gofigure.ReportValue, "Database host"))
+	db.Add(gofigure.Optional("Port", "port", &port, 5432,
+		gofigure.NamedSources, gofigure.ReportValue, "Database port"))
+	db.Add(gofigure.Required("Password", "password", &password,
+		gofigure.NamedSources, gofigure.MaskValue, "Database password"))
+	config.Group("service").Add(gofigure.Optional("Name", "name", &name, "",
+		gofigure.NamedSources, gofigure.ReportValue, "Service name"))
+
+	err := config.ParseUsing([]string{"--password", "secret", "--name", "my service",
+		"--host", "db"})
+
+	assert.NoError(t, err)
+
+	return config.Report()
+}
+
+func ExampleReport_Text() {
+	var host string
+
+	var port int
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	group := config.Group("database")
+	group.Add(gofigure.Optional("Host", "host", &host, "localhost",
+		gofigure.NamedSources, gofigure.ReportValue, "Database host"))
+	group.Add(gofigure.Optional("Port", "port", &port, 5432,
+		gofigure.NamedSources, gofigure.ReportValue, "Database port"))
+
+	if err := config.ParseUsing([]string{"--port", "6543"}); err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	fmt.Print(config.Report().Text(gofigure.WithSource))
+
+	// Output:
+	// database:
+	//   Host  localhost  default value
+	//   Port  6543       flag
+}
+
+func TestReport(t *testing.T) {
+	t.Run("Entries are in declaration order", func(t *testing.T) {
+		t.Parallel()
+
+		r := report(t)
+
+		assert.Len(t, r, 2)
+		assert.Equal(t, "Host", r[0].Entries[0].Name)
+		assert.Equal(t, "Port", r[0].Entries[1].Name)
+		assert.Equal(t, "Password", r[0].Entries[2].Name)
+		assert.Equal(t, "SET", r[0].Entries[2].Value)
+		assert.Equal(t, gofigure.Flag, r[0].Entries[2].Source)
+		assert.Equal(t, "SET", r[0].Values["Password"])
+	})
+
+	t.Run("JSON keeps declaration order", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := report(t).JSON(0)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"group": "database", "settings": [
+				{"name": "Host", "value": "db"},
+				{"name": "Port", "value": "5432"},
+				{"name": "Password", "value": "SET"}
+			]},
+			{"group": "service", "settings": [
+				{"name": "Name", "value": "my service"}
+			]}
+		]`, string(b))
+	})
+
+	t.Run("YAML quotes values where needed", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "database:\n  Host: db\n  Port: \"5432\"\n  Password: SET\n"+
+			"service:\n  Name: my service\n", report(t).YAML(0))
+		assert.Contains(t, report(t).YAML(gofigure.WithSource),
+			"  Port:\n    value: \"5432\"\n    source: default value\n")
+	})
+
+	t.Run("Logfmt has one line per setting", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "group=database setting=Host value=db source=flag provenance=--host\n"+
+			"group=database setting=Port value=5432 source=\"default value\"\n"+
+			"group=database setting=Password value=SET source=flag provenance=--password\n"+
+			"group=service setting=Name value=\"my service\" source=flag provenance=--name\n",
+			report(t).Logfmt(gofigure.WithSource|gofigure.WithProvenance))
+	})
+}
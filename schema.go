@@ -0,0 +1,163 @@
+package gofigure
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// SchemaVersion is the JSON Schema dialect used by Schema.
+const SchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema returns a JSON Schema document describing the external configuration
+// file for this Configuration. Every Setting with a Key Parameter, including
+// those on Commands, is described. A Setting is only marked as required in
+// the schema if it is required and can only be set using a Key.
+func (c *Configuration) Schema() ([]byte, error) {
+	properties := map[string]any{}
+	required := []string{}
+
+	for _, ref := range c.references(nil) {
+		for _, group := range ref.groups {
+			for _, setting := range group.Settings {
+				name, ok := setting.key()
+
+				if _, exists := properties[name]; !ok || exists {
+					continue
+				}
+
+				properties[name] = setting.schema()
+
+				if setting.keyRequired() {
+					required = append(required, name)
+				}
+			}
+		}
+	}
+
+	b, err := json.MarshalIndent(map[string]any{
+		"$schema":              SchemaVersion,
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}, "", "  ")
+
+	if err != nil {
+		return b, fmt.Errorf("failed to generate schema: %w", err)
+	}
+
+	return b, nil
+}
+
+// keyRequired returns true if the Setting is required and can only be set
+// using a Key, in which case a config file must provide it.
+func (s Setting) keyRequired() bool {
+	_, ok := s.key()
+
+	return ok && s.Value.base == nil && len(s.Parameters) == 1 &&
+		len(s.Requirements) == 0 && s.Derivation == nil
+}
+
+// key returns the name of the Key Parameter for the Setting, if it has one.
+func (s Setting) key() (string, bool) {
+	for _, parameter := range s.Parameters {
+		if parameter.Source == Key {
+			return parameter.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// schema for the Setting, derived from the type of Value.Ptr, any default,
+// and any Validators.
+func (s Setting) schema() map[string]any {
+	schema := map[string]any{}
+
+	if s.Value.enum == nil {
+		schema = schemaType(s.Value.Ptr)
+	}
+
+	if s.Value.Layout != "" {
+		delete(schema, "format")
+	}
+
+	schema["title"] = s.Value.Name
+	schema["description"] = s.Value.Description
+
+	for _, validator := range s.Validators {
+		for k, v := range validator.Schema {
+			schema[k] = v
+		}
+	}
+
+	if s.Value.base != nil && s.Derivation == nil && !s.Mask.Contains(HideUnset) {
+		if s.Value.enum != nil {
+			schema["default"] = s.Value.format(s.Value.base)
+
+			return schema
+		}
+
+		switch base := s.Value.base.(type) {
+		case Secret:
+		case time.Duration, time.Time, Date:
+			schema["default"] = format(base, s.Value.Layout)
+		case *url.URL:
+			if base != nil {
+				schema["default"] = base.String()
+			}
+		case *time.Location:
+			schema["default"] = base.String()
+		default:
+			schema["default"] = base
+		}
+	}
+
+	return schema
+}
+
+// schemaType returns the JSON Schema type for the given pointer, including
+// any bounds implied by the type.
+func schemaType(ptr any) map[string]any {
+	switch ptr.(type) {
+	case *time.Duration:
+		return map[string]any{"type": "string"}
+	case *ByteSize:
+		return map[string]any{"type": []string{"integer", "string"}, "minimum": 0}
+	case *time.Time:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case *Date:
+		return map[string]any{"type": "string", "format": "date"}
+	case **url.URL:
+		return map[string]any{"type": "string", "format": "uri"}
+	}
+
+	t := reflect.TypeOf(ptr).Elem()
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		maximum := int64(math.MaxInt64) >> (64 - t.Bits())
+
+		return map[string]any{
+			"type":    "integer",
+			"minimum": -maximum - 1,
+			"maximum": maximum,
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{
+			"type":    "integer",
+			"minimum": 0,
+			"maximum": uint64(math.MaxUint64) >> (64 - t.Bits()),
+		}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
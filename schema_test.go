@@ -0,0 +1,145 @@
+package gofigure_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleConfiguration_Schema() {
+	var (
+		name    string
+		port    uint16
+		timeout time.Duration
+		tls     bool
+	)
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	config.AddConfigFile(gofigure.CommandLine)
+
+	group := config.Group("settings")
+	group.Add(gofigure.Required("Name", "name", &name, gofigure.Key,
+		gofigure.ReportValue, "Application name"))
+	group.Add(gofigure.Optional("Port", "port", &port, 8080,
+		gofigure.NamedSources, gofigure.ReportValue, "Port to listen on"))
+	group.Add(gofigure.Optional("Timeout", "timeout", &timeout, time.Minute,
+		gofigure.NamedSources, gofigure.ReportValue, "Request timeout"))
+	group.Add(gofigure.Optional("TLS", "tls", &tls, false, gofigure.Key,
+		gofigure.ReportValue, "Use TLS"))
+
+	schema, err := config.Schema()
+
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(string(schema))
+
+	// Output:
+	// {
+	//   "$schema": "https://json-schema.org/draft/2020-12/schema",
+	//   "additionalProperties": false,
+	//   "properties": {
+	//     "name": {
+	//       "description": "Application name",
+	//       "title": "Name",
+	//       "type": "string"
+	//     },
+	//     "port": {
+	//       "default": 8080,
+	//       "description": "Port to listen on",
+	//       "maximum": 65535,
+	//       "minimum": 0,
+	//       "title": "Port",
+	//       "type": "integer"
+	//     },
+	//     "timeout": {
+	//       "default": "1m0s",
+	//       "description": "Request timeout",
+	//       "title": "Timeout",
+	//       "type": "string"
+	//     },
+	//     "tls": {
+	//       "default": false,
+	//       "description": "Use TLS",
+	//       "title": "TLS",
+	//       "type": "boolean"
+	//     }
+	//   },
+	//   "required": [
+	//     "name"
+	//   ],
+	//   "type": "object"
+	// }
+}
+
+func TestConfiguration_Schema(t *testing.T) {
+	t.Run("Integer bounds are derived from the type", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			small int8
+			large int64
+			f     float32
+		)
+
+		config := gofigure.NewConfiguration("")
+		group := config.Group("settings")
+		group.Add(gofigure.Required("Small", "small", &small, gofigure.Key,
+			gofigure.ReportValue, "small"))
+		group.Add(gofigure.Required("Large", "large", &large, gofigure.Key,
+			gofigure.ReportValue, "large"))
+		group.Add(gofigure.Required("Float", "float", &f, gofigure.Key,
+			gofigure.ReportValue, "float"))
+
+		var schema struct {
+			Properties map[string]struct {
+				Type    string
+				Minimum *json.Number
+				Maximum *json.Number
+			}
+		}
+
+		b, err := config.Schema()
+
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(b, &schema))
+		assert.Equal(t, "-128", schema.Properties["small"].Minimum.String())
+		assert.Equal(t, "127", schema.Properties["small"].Maximum.String())
+		assert.Equal(t, "-9223372036854775808", schema.Properties["large"].Minimum.String())
+		assert.Equal(t, "9223372036854775807", schema.Properties["large"].Maximum.String())
+		assert.Equal(t, "number", schema.Properties["float"].Type)
+		assert.Nil(t, schema.Properties["float"].Minimum)
+	})
+
+	t.Run("Keys on commands are included once", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b, c string
+
+		config := gofigure.NewConfiguration("")
+		config.Group("settings").Add(gofigure.Optional("A", "a", &a, "a",
+			gofigure.Key, gofigure.HideUnset, "root"))
+		config.Command("cmd", "cmd").Group("settings").Add(gofigure.Optional(
+			"A", "a", &b, "b", gofigure.Key, gofigure.ReportValue, "command"))
+		config.Command("cmd", "cmd").Group("settings").Add(gofigure.Optional(
+			"C", "c", &c, "c", gofigure.Key, gofigure.ReportValue, "command"))
+
+		var schema struct {
+			Properties map[string]map[string]any
+		}
+
+		data, err := config.Schema()
+
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(data, &schema))
+		assert.Len(t, schema.Properties, 2)
+		assert.Equal(t, "root", schema.Properties["a"]["description"])
+		assert.NotContains(t, schema.Properties["a"], "default")
+		assert.Equal(t, "c", schema.Properties["c"]["default"])
+	})
+}
@@ -0,0 +1,64 @@
+package gofigure
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// Secret holds a value that must not be printed, logged, or marshalled by
+// accident. All string representations of a Secret are redacted, and the
+// value can only be accessed using Reveal. A Setting for a Secret always uses
+// MaskValue.
+type Secret string
+
+// Reveal the value of the Secret.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String returns the redacted form of the Secret.
+func (s Secret) String() string {
+	return Redacted
+}
+
+// GoString returns the redacted form of the Secret.
+func (s Secret) GoString() string {
+	return fmt.Sprintf("gofigure.Secret(%q)", Redacted)
+}
+
+// MarshalJSON returns the redacted form of the Secret as a JSON string.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+// LogValue returns the redacted form of the Secret for use with slog.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// plain returns the string form of the value, revealing any Secret.
+func plain(value any) string {
+	if secret, ok := value.(Secret); ok {
+		return secret.Reveal()
+	}
+
+	return fmt.Sprint(value)
+}
+
+// secretMask returns the mask with MaskValue added if the pointer is to a
+// Secret.
+func secretMask(ptr any, mask Mask) Mask {
+	if isSecret(ptr) {
+		mask |= MaskValue
+	}
+
+	return mask
+}
+
+// isSecret returns true if the pointer is to a Secret.
+func isSecret(ptr any) bool {
+	_, ok := ptr.(*Secret)
+
+	return ok
+}
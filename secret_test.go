@@ -0,0 +1,64 @@
+package gofigure_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSecret() {
+	var password gofigure.Secret
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	config.Group("database").Add(gofigure.Required("Password", "password",
+		&password, gofigure.Flag, gofigure.ReportValue, "Database password"))
+
+	if err := config.ParseUsing([]string{"--password", "hunter2"}); err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	b, _ := json.Marshal(map[string]any{"password": password})
+
+	fmt.Println(password)
+	fmt.Printf("%#v\n", password)
+	fmt.Println(string(b))
+	fmt.Println(config.Report()[0].Values["Password"])
+	fmt.Println(password.Reveal())
+
+	// Output:
+	// REDACTED
+	// gofigure.Secret("REDACTED")
+	// {"password":"REDACTED"}
+	// SET
+	// hunter2
+}
+
+func TestSecret_LogValue(t *testing.T) {
+	t.Run("Secrets are redacted in logs", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(buf, nil))
+
+		logger.Info("login", "password", gofigure.Secret("hunter2"))
+
+		assert.Contains(t, buf.String(), "password=REDACTED")
+		assert.NotContains(t, buf.String(), "hunter2")
+	})
+}
+
+func TestSecret_Validators(t *testing.T) {
+	t.Run("Validators check the revealed value", func(t *testing.T) {
+		t.Parallel()
+
+		password := gofigure.Secret("hunter2")
+
+		assert.NoError(t, gofigure.Matches("^hunter[0-9]$").Validate(password))
+		assert.NoError(t, gofigure.NotEmpty().Validate(password))
+	})
+}
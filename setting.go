@@ -3,15 +3,30 @@ package gofigure
 import (
 	"errors"
 	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
 )
 
 // Setting in a Configuration. A Setting takes values from a set of Parameters
 // and applies them to a Value. The Mask is used when generating a Display
-// value.
+// value. The Completion is used when generating shell completion scripts, and
+// the Validators are used to check the Value once parsing is complete. If there
+// are any Requirements the Setting is only required when one of them is met.
+// The Derivation, if set, provides a default value computed at parse time. If
+// Interpolate is set then references in string values are expanded before
+// they are applied (see Settings.Interpolate). If ReadFiles is set then config
+// file values starting with FilePrefix are read from the named file.
 type Setting struct {
-	Value      *Value
-	Parameters Parameters
-	Mask       Mask
+	Value        *Value
+	Parameters   Parameters
+	Mask         Mask
+	Completion   Completion
+	Validators   []Validator
+	Requirements []Condition
+	Derivation   *Derivation
+	Interpolate  bool
+	ReadFiles    bool
 }
 
 type Settings []*Setting
@@ -24,13 +39,19 @@ var ErrInvalidValue = errors.New("invalid value")
 // defined sources. Combine multiple sources with | (e.g. Flag | EnvVar). The
 // given name is used for each source with Flag and Key using the name as is,
 // EnvSuffix set to the uppercase version of the name, and ShortFlag set to the
-// first character of name.
+// first character of name. Secret values always use MaskValue. If ptr is nil
+// a new variable is allocated to hold the value, which can be read using
+// Lookup or GetValue.
 func Optional[T Type](name, param string, ptr *T, value T, sources Source,
 	mask Mask, description string) *Setting {
+	if ptr == nil {
+		ptr = new(T)
+	}
+
 	return &Setting{
 		Value:      NewValue(name, ptr, value, Default, description),
 		Parameters: NewParameters(param, sources),
-		Mask:       mask,
+		Mask:       secretMask(ptr, mask),
 	}
 }
 
@@ -39,14 +60,20 @@ func Optional[T Type](name, param string, ptr *T, value T, sources Source,
 // sources with | (e.g. Flag | EnvVar). The given name is used for each source
 // with Flag and Key using the name as is, EnvSuffix set to the uppercase
 // version of the name, and ShortFlag set to the first character of name.
+// Secret values always use MaskValue. If ptr is nil a new variable is
+// allocated to hold the value, which can be read using Lookup or GetValue.
 func Required[T Type](name, param string, ptr *T, sources Source, mask Mask,
 	description string) *Setting {
 	var value T
 
+	if ptr == nil {
+		ptr = new(T)
+	}
+
 	return &Setting{
 		Value:      NewValue(name, ptr, value, None, description),
 		Parameters: NewParameters(param, sources),
-		Mask:       mask,
+		Mask:       secretMask(ptr, mask),
 	}
 }
 
@@ -61,19 +88,36 @@ func (s Setting) Accepts(parameter Parameter) bool {
 	return false
 }
 
+// Matches returns true if any of the Setting's Parameters match the given
+// Parameter, regardless of whether the Setting Accepts it.
+func (s Setting) Matches(parameter Parameter) bool {
+	for _, p := range s.Parameters {
+		if p.Matches(parameter) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Display string for the Setting. The string should only be displayed if
-// Display returns true, otherwise it should be hidden.
+// Display returns true, otherwise it should be hidden. Values set via a
+// Credential are masked unless the Mask contains RevealCredential.
 func (s Setting) Display() (string, bool) {
 	var value string
 
 	unset := None
 
+	if s.Value != nil && s.Value.Source == Credential && !s.Mask.Contains(RevealCredential) {
+		s.Mask |= MaskSet
+	}
+
 	if s.Value == nil {
 		return Invalid, false
 	} else if err := s.Value.Validate(); err != nil {
 		value = Invalid
 	} else {
-		value = fmt.Sprintf("%v", Dereference(s.Value.Ptr))
+		value = s.Value.format(Dereference(s.Value.Ptr))
 	}
 
 	if !s.Mask.Contains(DefaultIsSet) {
@@ -102,6 +146,72 @@ func (s Setting) display(value string, unset Source) (string, bool) {
 	return value, display
 }
 
+// notes on the Setting for use in usage and reference documentation, such as
+// the expected format, the default value, when the Setting is required, and
+// any Validators. Defaults are omitted if the Mask hides unset values, if they
+// are nil pointers, or for Secrets.
+func (s Setting) notes() []string {
+	var notes []string
+
+	if hint := hint(s.Value.Ptr); hint != "" {
+		notes = append(notes, hint)
+	}
+
+	switch {
+	case s.Derivation != nil:
+		notes = append(notes, fmt.Sprintf("default: %s", s.Derivation.Description))
+	case s.Value.base == nil && len(s.Requirements) == 0:
+		notes = append(notes, "required")
+	case s.Value.base == nil:
+	case s.Mask.Contains(HideUnset), isSecret(s.Value.Ptr), isNil(s.Value.base):
+	case fmt.Sprint(s.Value.base) != "":
+		notes = append(notes, fmt.Sprintf("default: %s", s.Value.format(s.Value.base)))
+	}
+
+	if len(s.Requirements) > 0 {
+		notes = append(notes, s.requirement())
+	}
+
+	for _, validator := range s.Validators {
+		if validator.Description != "" {
+			notes = append(notes, validator.Description)
+		}
+	}
+
+	return notes
+}
+
+// isNil returns true if the value is a nil pointer.
+func isNil(value any) bool {
+	v := reflect.ValueOf(value)
+
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// hint at the expected format for types where the format isn't obvious.
+func hint(ptr any) string {
+	switch ptr.(type) {
+	case **url.URL:
+		return "URL"
+	case *netip.Addr:
+		return "IP address"
+	case *netip.Prefix:
+		return "IP prefix"
+	case *HostPort:
+		return "host:port"
+	case *Path:
+		return "path"
+	default:
+		return ""
+	}
+}
+
+// missing returns the error used when the Setting is required but not set.
+func (s Setting) missing(prefix string) error {
+	return NewConfigError(ErrMissingRequiredOption, fmt.Errorf("%w: %s",
+		ErrMissingRequiredOption, s.Parameters.Format(prefix)), s.Parameters...)
+}
+
 // External configuration file paths defined by these settings.
 func (s Settings) External() []string {
 	var externals []string
@@ -117,8 +227,27 @@ func (s Settings) External() []string {
 	return externals
 }
 
-// Map the options to the settings, ignoring any errors provided.
+// Map the options to the settings, ignoring any errors provided. References in
+// values are expanded using Interpolate before the values are applied.
 func (s Settings) Map(options map[Parameter]any, ignore ...error) error {
+	return s.mapping(options, false, ignore...)
+}
+
+// mapping maps the options as for Map. If interpolate is true then references
+// are expanded for all Settings.
+func (s Settings) mapping(options map[Parameter]any, interpolate bool, ignore ...error) error {
+	expanded, err := s.interpolate(options, interpolate)
+
+	if err != nil {
+		return err
+	}
+
+	return s.assign(expanded, ignore...)
+}
+
+// assign the options to the settings without expanding references, ignoring
+// any errors provided.
+func (s Settings) assign(options Options, ignore ...error) error {
 	for parameter, value := range options {
 		err := s.Apply(parameter, value)
 
@@ -132,7 +261,10 @@ func (s Settings) Map(options map[Parameter]any, ignore ...error) error {
 
 		switch {
 		case errors.Is(err, ErrUnexpectedArgument):
-			return NewConfigError(ErrUnexpectedArgument, err, parameter)
+			e := NewConfigError(ErrUnexpectedArgument, err, parameter)
+			e.Suggestions = s.Suggest(parameter)
+
+			return e
 		case err != nil:
 			return NewConfigError(fmt.Errorf("%w '%v'", ErrInvalidValue, value),
 				err, parameter)
@@ -144,15 +276,44 @@ func (s Settings) Map(options map[Parameter]any, ignore ...error) error {
 
 // Apply the Parameter to the correct Setting in the set. Apply will return an
 // error if the relevant Setting cannot be set, or if no Settings have been set.
+// A Parameter for a Setting that has already been set from a Source with a
+// higher precedence is ignored. Values that reference a file, either from an
+// environment variable with FileSuffix or a Key value with FilePrefix on a
+// Setting with ReadFiles set, are read from the file, which is recorded as the
+// Provenance of the Value. Errors for values that were decrypted or read from a
+// file do not include the value.
 func (s Settings) Apply(parameter Parameter, value any) error {
+	var overridden bool
+
 	for _, setting := range s {
 		if !setting.Accepts(parameter) {
+			overridden = overridden || setting.Matches(parameter)
+
 			continue
-		} else if err := setting.Value.Assign(value, parameter.Source); err != nil {
+		}
+
+		resolved, provenance, err := resolve(parameter, value, setting.prefixed())
+
+		if err == nil {
+			err = setting.Value.Assign(resolved, parameter.Source)
+
+			if err != nil && hidden(value, resolved) {
+				err = fmt.Errorf("%w: cannot assign value from %s to %s",
+					ErrInvalidValue, provenance, setting.Value.Name)
+			}
+		}
+
+		if err != nil {
 			return fmt.Errorf("failed to apply %s: %w", parameter, err)
-		} else {
-			return nil
 		}
+
+		setting.Value.Provenance = provenance
+
+		return nil
+	}
+
+	if overridden {
+		return nil
 	}
 
 	return fmt.Errorf("%w: %s", ErrUnexpectedArgument, parameter)
@@ -23,6 +23,13 @@ const (
 	// via this environment variable.
 	EnvVar = Source(1 << iota)
 
+	// A Credential provided as a file by the service manager, either in the
+	// systemd $CREDENTIALS_DIRECTORY or in /run/secrets. Can be used either to
+	// indicate the Value can be set via this credential, or that it has been
+	// set via this credential. Values set via a Credential are masked unless
+	// the Setting uses RevealCredential.
+	Credential = Source(1 << iota)
+
 	// A ShortFlag on the command line. Can be used either to indicate the
 	// Value can be set via this flag, or that it has been set via this flag.
 	ShortFlag = Source(1 << iota)
@@ -68,6 +75,8 @@ func (s Source) String() string {
 		return "config file key"
 	case EnvVar:
 		return "environment variable"
+	case Credential:
+		return "credential"
 	case ShortFlag:
 		return "short flag"
 	case Flag:
@@ -28,6 +28,7 @@ func ExampleSource_String() {
 	fmt.Println(gofigure.Default)
 	fmt.Println(gofigure.Key)
 	fmt.Println(gofigure.EnvVar)
+	fmt.Println(gofigure.Credential)
 	fmt.Println(gofigure.ShortFlag)
 	fmt.Println(gofigure.Flag)
 	fmt.Println(gofigure.Reference)
@@ -39,6 +40,7 @@ func ExampleSource_String() {
 	// default value
 	// config file key
 	// environment variable
+	// credential
 	// short flag
 	// flag
 	// reference value
@@ -0,0 +1,167 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// Strictness defines how unknown config file keys and environment variables
+// are handled when parsing.
+type Strictness uint8
+
+const (
+	// IgnoreUnknown will silently ignore unknown keys and variables.
+	IgnoreUnknown = Strictness(iota)
+
+	// WarnUnknown will report unknown keys and variables using the Warn
+	// function on the Configuration, or the standard logger if Warn is nil.
+	WarnUnknown
+
+	// RejectUnknown will cause parsing to fail on unknown keys and variables.
+	RejectUnknown
+)
+
+func (s Strictness) String() string {
+	switch s {
+	case IgnoreUnknown:
+		return "ignore unknown"
+	case WarnUnknown:
+		return "warn on unknown"
+	case RejectUnknown:
+		return "reject unknown"
+	default:
+		return fmt.Sprintf("strictness: %d", s)
+	}
+}
+
+// mapKeys maps the options from an external configuration file onto the
+// settings, handling unknown keys using the UnknownKeys Strictness. Keys for
+// Settings on any Command are known, even if the Command isn't selected, so a
+// config file can be shared between Commands. References are expanded using
+// all the options before any are applied, and encrypted values are decrypted
+// as they are applied, or when they are referenced.
+func (c *Configuration) mapKeys(settings Settings, options Options) error {
+	options, err := c.seal(options)
+
+	if err != nil {
+		return err
+	}
+
+	if options, err = settings.interpolate(options, c.Interpolate); err != nil {
+		return err
+	}
+
+	known := c.keys()
+
+	for _, parameter := range options.sorted() {
+		err = settings.assign(Options{parameter: options[parameter]})
+
+		switch {
+		case errors.Is(err, ErrUnexpectedArgument) && known[parameter.Name]:
+			err = nil
+		case errors.Is(err, ErrUnexpectedArgument):
+			err = c.unknown(c.UnknownKeys, err)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keys returns the names of the Key Parameters for the Settings on the
+// Configuration and on all Commands.
+func (c *Configuration) keys() map[string]bool {
+	known := map[string]bool{}
+
+	for _, ref := range c.references(nil) {
+		for _, group := range ref.groups {
+			for _, setting := range group.Settings {
+				if name, ok := setting.key(); ok {
+					known[name] = true
+				}
+			}
+		}
+	}
+
+	return known
+}
+
+// unknownEnvironment checks the environment for variables using the
+// Configuration Prefix that don't match an EnvVar Parameter on any Setting,
+// handling them using the UnknownEnv Strictness. Variables are only checked if
+// the Configuration has a Prefix.
+func (c *Configuration) unknownEnvironment() error {
+	if c.Prefix == "" || c.UnknownEnv == IgnoreUnknown {
+		return nil
+	}
+
+	known := map[string]Parameter{}
+
+	for _, ref := range c.references(nil) {
+		for _, group := range ref.groups {
+			for _, setting := range group.Settings {
+				for _, parameter := range setting.Parameters {
+					if parameter.Source == EnvVar {
+						parameter.Stub = ref.prefix
+						known[parameter.FullName()] = parameter
+						known[parameter.FullName()+FileSuffix] = parameter
+					}
+				}
+			}
+		}
+	}
+
+	candidates := make(Parameters, 0, len(known))
+	stub := c.Prefix + "_"
+
+	for name, parameter := range known {
+		if name == parameter.FullName() {
+			candidates = append(candidates, parameter)
+		}
+	}
+
+	for _, env := range os.Environ() {
+		name, _, _ := strings.Cut(env, "=")
+
+		if _, ok := known[name]; ok || !strings.HasPrefix(name, stub) {
+			continue
+		}
+
+		parameter := Parameter{Name: strings.TrimPrefix(name, stub),
+			Source: EnvVar, Stub: c.Prefix}
+		err := NewConfigError(ErrUnexpectedArgument,
+			fmt.Errorf("%w: %s", ErrUnexpectedArgument, parameter), parameter)
+		err.Suggestions = suggest(parameter, candidates)
+
+		if err := c.unknown(c.UnknownEnv, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unknown handles the error for an unknown key or variable using the given
+// Strictness.
+func (c *Configuration) unknown(strictness Strictness, err error) error {
+	switch strictness {
+	case RejectUnknown:
+		return err
+	case WarnUnknown:
+		if c.Warn != nil {
+			c.Warn(err)
+		} else {
+			log.Print(c.Format(err))
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
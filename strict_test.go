@@ -0,0 +1,181 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleStrictness_String() {
+	fmt.Println(gofigure.IgnoreUnknown)
+	fmt.Println(gofigure.WarnUnknown)
+	fmt.Println(gofigure.RejectUnknown)
+	fmt.Println(gofigure.Strictness(9))
+
+	// Output:
+	// ignore unknown
+	// warn on unknown
+	// reject unknown
+	// strictness: 9
+}
+
+func TestConfiguration_UnknownKeys(t *testing.T) {
+	setup := func(strictness gofigure.Strictness) *gofigure.Configuration {
+		var file gofigure.External
+
+		config := gofigure.NewConfiguration("")
+		config.UnknownKeys = strictness
+		config.Group("test").Add(gofigure.Required("config", "c", &file,
+			gofigure.ShortFlag, gofigure.ReportValue, "config file"))
+		config.Group("test").Add(gofigure.Optional("name", "name", new(string),
+			"", gofigure.Key, gofigure.ReportValue, "name"))
+
+		return config
+	}
+
+	t.Run("Unknown keys are ignored by default", func(t *testing.T) {
+		t.Parallel()
+
+		config := setup(gofigure.IgnoreUnknown)
+
+		assert.NoError(t, config.ParseUsing([]string{"-c", "testdata/config.json"}))
+	})
+
+	t.Run("Unknown keys can be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		config := setup(gofigure.RejectUnknown)
+		err := config.ParseUsing([]string{"-c", "testdata/config.json"})
+
+		assert.ErrorIs(t, err, gofigure.ErrUnexpectedArgument)
+		assert.Equal(t, "unexpected argument: [JSON key: \"address\"]",
+			config.Format(err))
+	})
+
+	t.Run("Keys for other Commands are not unknown", func(t *testing.T) {
+		t.Parallel()
+
+		config := setup(gofigure.RejectUnknown)
+		config.Command("migrate", "migrate")
+		serve := config.Command("serve", "serve").Group("server")
+
+		for _, key := range []string{"address", "duration", "int", "float"} {
+			serve.Add(gofigure.Optional(key, key, new(string), "", gofigure.Key,
+				gofigure.ReportValue, key))
+		}
+
+		assert.NoError(t, config.ParseUsing([]string{"migrate", "-c", "testdata/config.json"}))
+	})
+
+	t.Run("Unknown keys can be warned about", func(t *testing.T) {
+		t.Parallel()
+
+		var warnings []string
+
+		config := setup(gofigure.WarnUnknown)
+		config.Warn = func(err error) {
+			warnings = append(warnings, config.Format(err))
+		}
+
+		assert.NoError(t, config.ParseUsing([]string{"-c", "testdata/config.json"}))
+		assert.Equal(t, []string{
+			"unexpected argument: [JSON key: \"address\"]",
+			"unexpected argument: [JSON key: \"duration\"]",
+			"unexpected argument: [JSON key: \"float\"]",
+			"unexpected argument: [JSON key: \"int\"]",
+		}, warnings)
+	})
+}
+
+//nolint:paralleltest // Testing environment variables.
+func TestConfiguration_UnknownEnv(t *testing.T) {
+	setup := func(t *testing.T, strictness gofigure.Strictness) *gofigure.Configuration {
+		t.Helper()
+
+		t.Setenv("GOFIGURE_STRICT_NAME", "name")
+		t.Setenv("GOFIGURE_STRICT_SERVE_PORT", "80")
+		t.Setenv("GOFIGURE_STRICT_TIMOUT", "1s")
+
+		config := gofigure.NewConfiguration("GOFIGURE_STRICT")
+		config.CommandEnv = true
+		config.UnknownEnv = strictness
+		config.Group("test").Add(gofigure.Optional("name", "name", new(string),
+			"", gofigure.EnvVar, gofigure.ReportValue, "name"))
+		config.Command("serve", "serve").Group("test").Add(gofigure.Optional(
+			"port", "port", new(int), 0, gofigure.EnvVar, gofigure.ReportValue,
+			"port"))
+
+		return config
+	}
+
+	t.Run("Unknown variables are ignored by default", func(t *testing.T) {
+		config := setup(t, gofigure.IgnoreUnknown)
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+	})
+
+	t.Run("Unknown variables can be rejected", func(t *testing.T) {
+		config := setup(t, gofigure.RejectUnknown)
+		err := config.ParseUsing([]string{})
+
+		assert.ErrorIs(t, err, gofigure.ErrUnexpectedArgument)
+		assert.Equal(t, "unexpected argument: [env GOFIGURE_STRICT_TIMOUT]",
+			config.Format(err))
+	})
+
+	t.Run("Unknown variables can be warned about", func(t *testing.T) {
+		var warnings []error
+
+		config := setup(t, gofigure.WarnUnknown)
+		config.Warn = func(err error) { warnings = append(warnings, err) }
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+		assert.Len(t, warnings, 1)
+		assert.ErrorIs(t, warnings[0], gofigure.ErrUnexpectedArgument)
+	})
+
+	t.Run("Variables are not checked without a prefix", func(t *testing.T) {
+		config := setup(t, gofigure.RejectUnknown)
+		config.Prefix = ""
+
+		assert.NoError(t, config.ParseUsing([]string{}))
+	})
+}
+
+//nolint:paralleltest // Testing environment variables.
+func TestConfiguration_Overridden(t *testing.T) {
+	t.Run("Overridden keys and variables are not unknown", func(t *testing.T) {
+		var (
+			file gofigure.External
+			name string
+		)
+
+		t.Setenv("GOFIGURE_OVERRIDE_NAME", "env")
+
+		config := gofigure.NewConfiguration("GOFIGURE_OVERRIDE")
+		config.UnknownKeys = gofigure.RejectUnknown
+		config.UnknownEnv = gofigure.RejectUnknown
+		config.Group("test").Add(gofigure.Required("config", "c", &file,
+			gofigure.ShortFlag, gofigure.ReportValue, "config file"))
+		config.Group("test").Add(gofigure.Required("name", "name", &name,
+			gofigure.NamedSources, gofigure.ReportValue, "name"))
+
+		group := config.Group("test")
+		group.Add(gofigure.Optional("address", "address", new(string), "",
+			gofigure.Key, gofigure.ReportValue, "address"))
+		group.Add(gofigure.Optional("duration", "duration", new(string), "",
+			gofigure.Key, gofigure.ReportValue, "duration"))
+		group.Add(gofigure.Optional("int", "int", new(int), 0,
+			gofigure.Key, gofigure.ReportValue, "int"))
+		group.Add(gofigure.Optional("float", "float", new(float64), 0,
+			gofigure.Key, gofigure.ReportValue, "float"))
+
+		err := config.ParseUsing([]string{"-c", "testdata/config.json",
+			"--name", "flag"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "flag", name)
+	})
+}
@@ -0,0 +1,122 @@
+package gofigure
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Suggest Parameters from the Settings that are similar to the given Parameter
+// and have the same Source. Similarity is based on the edit distance between
+// the names, and only the closest matches are returned. Single character names
+// never generate suggestions.
+func (s Settings) Suggest(parameter Parameter) Parameters {
+	var candidates Parameters
+
+	for _, setting := range s {
+		for _, p := range setting.Parameters {
+			if p.Source.Contains(parameter.Source) {
+				candidates = append(candidates, Parameter{
+					Name: p.Name, Source: parameter.Source, Stub: p.Stub})
+			}
+		}
+	}
+
+	return suggest(parameter, candidates)
+}
+
+// suggestCommand adds suggested Commands to an unexpected argument error if the
+// argument is in the position of a Command.
+func (c *Configuration) suggestCommand(err error, args []string) error {
+	var target ConfigError
+
+	if !errors.As(err, &target) || len(args) == 0 || len(target.Parameters) == 0 ||
+		target.Parameters[0].Name != args[0] {
+		return err
+	}
+
+	var candidates Parameters
+
+	commands := c.Commands
+
+	if c.Selected != nil {
+		commands = c.Selected.Commands
+	}
+
+	for _, cmd := range commands {
+		candidates = append(candidates, Parameter{Name: cmd.Name, Source: CommandLine})
+	}
+
+	target.Suggestions = suggest(target.Parameters[0], candidates)
+
+	return target
+}
+
+// suggest the closest candidates to the given Parameter.
+func suggest(parameter Parameter, candidates Parameters) Parameters {
+	var suggestions Parameters
+
+	name := strings.ToLower(parameter.FullName())
+	best := len(parameter.Name)/3 + 1
+
+	if best >= len(parameter.Name) {
+		best = len(parameter.Name) - 1
+	}
+
+	for _, candidate := range candidates {
+		d := distance(name, strings.ToLower(candidate.FullName()))
+
+		switch {
+		case d == 0 || d > best:
+		case d < best:
+			best = d
+			suggestions = Parameters{candidate}
+		default:
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].Name < suggestions[j].Name
+	})
+
+	return suggestions
+}
+
+// distance returns the Levenshtein distance between two strings.
+func distance(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	row := make([]int, len(y)+1)
+
+	for j := range row {
+		row[j] = j
+	}
+
+	for i := 1; i <= len(x); i++ {
+		diagonal := row[0]
+		row[0] = i
+
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+
+			above := row[j]
+			row[j] = diagonal + cost
+
+			if above+1 < row[j] {
+				row[j] = above + 1
+			}
+
+			if row[j-1]+1 < row[j] {
+				row[j] = row[j-1] + 1
+			}
+
+			diagonal = above
+		}
+	}
+
+	return row[len(y)]
+}
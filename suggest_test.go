@@ -0,0 +1,107 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSettings_Suggest() {
+	var timeout, timer, retries int
+
+	settings := gofigure.Settings{
+		gofigure.Optional("Timeout", "timeout", &timeout, 0,
+			gofigure.AllSources, gofigure.ReportValue, "timeout"),
+		gofigure.Optional("Timer", "timer", &timer, 0,
+			gofigure.AllSources, gofigure.ReportValue, "timer"),
+		gofigure.Optional("Retries", "retries", &retries, 0,
+			gofigure.AllSources, gofigure.ReportValue, "retries"),
+	}
+
+	fmt.Println(settings.Suggest(gofigure.Parameter{Name: "timout", Source: gofigure.Flag}))
+	fmt.Println(settings.Suggest(gofigure.Parameter{Name: "timeer", Source: gofigure.Flag}))
+	fmt.Println(settings.Suggest(gofigure.Parameter{Name: "RETRYS", Source: gofigure.EnvVar}))
+	fmt.Println(settings.Suggest(gofigure.Parameter{Name: "verbose", Source: gofigure.Flag}))
+	fmt.Println(settings.Suggest(gofigure.Parameter{Name: "x", Source: gofigure.ShortFlag}))
+
+	// Output:
+	// [--timeout]
+	// [--timer]
+	// [env RETRIES]
+	// []
+	// []
+}
+
+func TestConfiguration_Format_Suggestions(t *testing.T) {
+	t.Run("Misspelt flags are given suggestions", func(t *testing.T) {
+		t.Parallel()
+
+		var timeout int
+
+		config := gofigure.NewConfiguration("")
+		config.Group("test").Add(gofigure.Optional("Timeout", "timeout",
+			&timeout, 0, gofigure.Flag, gofigure.ReportValue, "timeout"))
+
+		err := config.ParseUsing([]string{"--timout", "1"})
+
+		assert.Equal(t, "unexpected argument: [--timout] (did you mean --timeout?)",
+			config.Format(err))
+	})
+
+	t.Run("Multiple suggestions can be given", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Group("test").Add(gofigure.Optional("Cat", "cat", new(bool),
+			false, gofigure.Flag, gofigure.ReportValue, "cat"))
+		config.Group("test").Add(gofigure.Optional("Cap", "cap", new(bool),
+			false, gofigure.Flag, gofigure.ReportValue, "cap"))
+
+		err := config.ParseUsing([]string{"--car"})
+
+		assert.Equal(t, "unexpected argument: [--car] "+
+			"(did you mean --cap or --cat?)", config.Format(err))
+	})
+
+	t.Run("Misspelt commands are given suggestions", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Command("migrate", "migrate").Command("down", "down")
+
+		err := config.ParseUsing([]string{"migrate", "donw"})
+
+		assert.Equal(t, "unexpected argument: [donw] (did you mean down?)",
+			config.Format(err))
+	})
+
+	t.Run("Only positional commands are suggested", func(t *testing.T) {
+		t.Parallel()
+
+		config := gofigure.NewConfiguration("")
+		config.Command("serve", "serve")
+
+		err := config.ParseUsing([]string{"--a", "b", "serv"})
+
+		assert.Equal(t, "unexpected argument: [serv]", config.Format(err))
+	})
+
+	//nolint:paralleltest // Testing environment variables.
+	t.Run("Misspelt variables are given suggestions", func(t *testing.T) {
+		t.Setenv("GOFIGURE_SUGGEST_SERVE_POTR", "80")
+
+		config := gofigure.NewConfiguration("GOFIGURE_SUGGEST")
+		config.CommandEnv = true
+		config.UnknownEnv = gofigure.RejectUnknown
+		config.Command("serve", "serve").Group("test").Add(gofigure.Optional(
+			"Port", "port", new(int), 0, gofigure.EnvVar, gofigure.ReportValue,
+			"port"))
+
+		err := config.ParseUsing([]string{})
+
+		assert.Equal(t, "unexpected argument: [env GOFIGURE_SUGGEST_SERVE_POTR] "+
+			"(did you mean env GOFIGURE_SUGGEST_SERVE_PORT?)", config.Format(err))
+	})
+}
@@ -0,0 +1,121 @@
+package gofigure
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Date without a time of day. Dates are given using DateLayout unless the
+// Value has a different Layout.
+type Date struct {
+	time.Time
+}
+
+// DateLayout is the default layout for a Date.
+const DateLayout = time.DateOnly
+
+// ParseDate parses a string using DateLayout.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateLayout, s)
+
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date: %w", err)
+	}
+
+	return Date{Time: t}, nil
+}
+
+// Layout sets the layout used to parse and report time.Time and Date values
+// for the Setting, returning the Setting. The layout is given in the form used
+// by time.Parse.
+func (s *Setting) Layout(layout string) *Setting {
+	s.Value.Layout = layout
+
+	return s
+}
+
+// String returns the Date using DateLayout.
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+// MarshalText returns the Date using DateLayout.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses the Date using DateLayout.
+func (d *Date) UnmarshalText(b []byte) (err error) {
+	*d, err = ParseDate(string(b))
+
+	return err
+}
+
+// MarshalJSON returns the Date as a JSON string using DateLayout, rather than
+// the RFC 3339 form used by time.Time.
+func (d Date) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(d.String())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal date: %w", err)
+	}
+
+	return b, nil
+}
+
+// UnmarshalJSON parses a JSON string using DateLayout.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("failed to unmarshal date: %w", err)
+	}
+
+	return d.UnmarshalText([]byte(s))
+}
+
+// timestamp parses string values for time.Time and Date Values using the
+// Value's Layout. Other values are returned as is.
+func (v *Value) timestamp(value any) (any, error) {
+	s, ok := value.(string)
+
+	if !ok || v.Layout == "" {
+		return value, nil
+	}
+
+	t, err := time.Parse(v.Layout, s)
+
+	if err != nil {
+		return value, fmt.Errorf("cannot parse %q using %q: %w", s, v.Layout, err)
+	}
+
+	if _, ok = v.Ptr.(*Date); ok {
+		return Date{Time: t}, nil
+	}
+
+	return t, nil
+}
+
+// format a value for display. Durations use FormatDuration, and time.Time and
+// Date values use the layout, or their default layout if the layout is empty.
+func format(value any, layout string) string {
+	switch v := value.(type) {
+	case time.Duration:
+		return FormatDuration(v)
+	case time.Time:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		return v.Format(layout)
+	case Date:
+		if layout == "" {
+			layout = DateLayout
+		}
+
+		return v.Format(layout)
+	default:
+		return fmt.Sprint(value)
+	}
+}
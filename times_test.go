@@ -0,0 +1,102 @@
+package gofigure_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSetting_Layout() {
+	var (
+		window  time.Time
+		cutover gofigure.Date
+		zone    *time.Location
+	)
+
+	config := gofigure.NewConfiguration("EXAMPLE")
+	config.AddConfigFile(gofigure.Flag)
+
+	group := config.Group("maintenance")
+	group.Add(gofigure.Required("Window", "window", &window,
+		gofigure.NamedSources, gofigure.ReportValue, "Start of the window").
+		Layout("02 Jan 06 15:04 MST"))
+	group.Add(gofigure.Required("Cutover", "cutover", &cutover,
+		gofigure.NamedSources, gofigure.ReportValue, "Cutover date"))
+	group.Add(gofigure.Optional("Zone", "zone", &zone, time.UTC,
+		gofigure.NamedSources, gofigure.ReportValue, "Time zone"))
+
+	err := config.ParseUsing([]string{"--config", "testdata/maintenance.json",
+		"--window", "01 Mar 26 02:00 UTC"})
+
+	if err != nil {
+		fmt.Println(config.Format(err))
+	}
+
+	fmt.Println(window.Format(time.RFC3339))
+	fmt.Println(cutover.Weekday())
+	fmt.Println(zone)
+	fmt.Println(config.Report()[1].Values)
+
+	// Output:
+	// 2026-03-01T02:00:00Z
+	// Wednesday
+	// America/New_York
+	// map[Cutover:2026-04-01 Window:01 Mar 26 02:00 UTC Zone:America/New_York]
+}
+
+func TestValue_Assign_Time(t *testing.T) {
+	t.Run("Times use RFC 3339 by default", func(t *testing.T) {
+		t.Parallel()
+
+		var when time.Time
+
+		value := gofigure.NewValue("when", &when, time.Time{}, gofigure.None, "when")
+
+		assert.NoError(t, value.Assign("2026-03-01T02:00:00+01:00", gofigure.Flag))
+		assert.Equal(t, time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC), when.UTC())
+		assert.Error(t, value.Assign("01 Mar 26", gofigure.Flag))
+	})
+
+	t.Run("Invalid values are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			date gofigure.Date
+			zone *time.Location
+		)
+
+		dateValue := gofigure.NewValue("date", &date, gofigure.Date{}, gofigure.None, "date")
+		zoneValue := gofigure.NewValue("zone", &zone, nil, gofigure.None, "zone")
+
+		assert.Error(t, dateValue.Assign("2026-13-01", gofigure.Flag))
+		assert.Error(t, zoneValue.Assign("Mars/Olympus_Mons", gofigure.Flag))
+
+		dateValue.Layout = "02/01/2006"
+
+		assert.NoError(t, dateValue.Assign("25/12/2026", gofigure.Flag))
+		assert.Equal(t, "2026-12-25", date.String())
+	})
+}
+
+func TestDate_MarshalJSON(t *testing.T) {
+	t.Run("Dates use DateLayout in JSON", func(t *testing.T) {
+		t.Parallel()
+
+		date, err := gofigure.ParseDate("2026-01-02")
+		assert.NoError(t, err)
+
+		b, err := json.Marshal(date)
+		assert.NoError(t, err)
+		assert.Equal(t, `"2026-01-02"`, string(b))
+
+		var decoded gofigure.Date
+
+		assert.NoError(t, json.Unmarshal(b, &decoded))
+		assert.Equal(t, date, decoded)
+		assert.Error(t, json.Unmarshal([]byte(`"2026-01-02T00:00:00Z"`), &decoded))
+	})
+}
@@ -0,0 +1,194 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSize is a number of bytes. Values can be given as a number of bytes, or
+// using a unit (e.g. 512MiB, 1.5GB, 4k). Units are case-insensitive. Decimal
+// units (kB, MB, GB, TB, PB) are powers of 1000, while binary units (KiB, MiB,
+// GiB, TiB, PiB) and the single letter units (K, M, G, T, P) are powers of
+// 1024. A ByteSize is formatted using whichever unit gives the shortest form.
+type ByteSize uint64
+
+// unit of measure for a ByteSize.
+type unit struct {
+	name string
+	size uint64
+}
+
+// ErrInvalidSize is returned if a ByteSize cannot be parsed.
+var ErrInvalidSize = errors.New("invalid byte size")
+
+// Common byte sizes.
+const (
+	Byte     = ByteSize(1)
+	Kilobyte = 1000 * Byte
+	Megabyte = 1000 * Kilobyte
+	Gigabyte = 1000 * Megabyte
+	Terabyte = 1000 * Gigabyte
+	Petabyte = 1000 * Terabyte
+	Kibibyte = 1024 * Byte
+	Mebibyte = 1024 * Kibibyte
+	Gibibyte = 1024 * Mebibyte
+	Tebibyte = 1024 * Gibibyte
+	Pebibyte = 1024 * Tebibyte
+)
+
+// Extended duration units.
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+//nolint:gochecknoglobals // Lookup tables.
+var (
+	binary  = []unit{{"PiB", 1 << 50}, {"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10}}
+	decimal = []unit{{"PB", 1e15}, {"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3}}
+	sizes   = map[string]uint64{
+		"": 1, "b": 1,
+		"k": 1 << 10, "kib": 1 << 10, "kb": 1e3,
+		"m": 1 << 20, "mib": 1 << 20, "mb": 1e6,
+		"g": 1 << 30, "gib": 1 << 30, "gb": 1e9,
+		"t": 1 << 40, "tib": 1 << 40, "tb": 1e12,
+		"p": 1 << 50, "pib": 1 << 50, "pb": 1e15,
+	}
+	days = regexp.MustCompile(`(\d+(?:\.\d*)?|\.\d+)([dw])`)
+)
+
+// ParseByteSize parses a string such as "512MiB", "1.5GB", or "4k" into a
+// ByteSize. Sizes must be a whole number of bytes.
+func ParseByteSize(s string) (ByteSize, error) {
+	n := strings.TrimSpace(s)
+	i := strings.LastIndexAny(n, "0123456789.") + 1
+	number, name := n[:i], strings.ToLower(strings.TrimSpace(n[i:]))
+	size, ok := sizes[name]
+
+	if !ok || number == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, s)
+	}
+
+	if u, err := strconv.ParseUint(number, 10, 64); err == nil {
+		if u > math.MaxUint64/size {
+			return 0, fmt.Errorf("%w: %q is too large", ErrInvalidValue, s)
+		}
+
+		return ByteSize(u * size), nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, s)
+	}
+
+	bytes, err := Convert(f*float64(size), uint64(0))
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a whole number of bytes in range", ErrInvalidValue, s)
+	}
+
+	return ByteSize(bytes.(uint64)), nil
+}
+
+// String returns the ByteSize using the unit that gives the shortest form,
+// with up to three decimal places.
+func (b ByteSize) String() string {
+	short := fmt.Sprintf("%dB", uint64(b))
+
+	for _, units := range [][]unit{binary, decimal} {
+		for _, u := range units {
+			if s, ok := b.in(u); ok && len(s) < len(short) {
+				short = s
+			}
+		}
+	}
+
+	return short
+}
+
+// in returns the ByteSize in the given unit, if it can be given exactly using
+// no more than three decimal places.
+func (b ByteSize) in(u unit) (string, bool) {
+	whole, remainder := uint64(b)/u.size, uint64(b)%u.size
+
+	if whole == 0 || (remainder*1000)%u.size != 0 {
+		return "", false
+	}
+
+	fraction := strings.TrimRight(fmt.Sprintf("%03d", remainder*1000/u.size), "0")
+
+	if fraction == "" {
+		return fmt.Sprintf("%d%s", whole, u.name), true
+	}
+
+	return fmt.Sprintf("%d.%s%s", whole, fraction, u.name), true
+}
+
+// ParseDuration parses a duration string as time.ParseDuration, but also
+// accepts days (d) and weeks (w), e.g. "7d", "2w", or "1d12h".
+func ParseDuration(s string) (time.Duration, error) {
+	hours := days.ReplaceAllStringFunc(s, func(match string) string {
+		parts := days.FindStringSubmatch(match)
+		f, _ := strconv.ParseFloat(parts[1], 64)
+
+		if parts[2] == "w" {
+			f *= 7
+		}
+
+		return strconv.FormatFloat(f*24, 'f', -1, 64) + "h"
+	})
+
+	d, err := time.ParseDuration(hours)
+
+	if err != nil && hours != s {
+		return d, fmt.Errorf("%w: duration %q: %w", ErrInvalidValue, s, err)
+	} else if err != nil {
+		return d, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+
+	return d, nil
+}
+
+// FormatDuration formats a duration in the form accepted by ParseDuration.
+// Durations of at least a day use days or weeks, otherwise time.Duration's
+// String form is used.
+func FormatDuration(d time.Duration) string {
+	if d < Day && d > -Day {
+		return d.String()
+	}
+
+	var sign string
+
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	if d%Week == 0 {
+		return fmt.Sprintf("%s%dw", sign, d/Week)
+	}
+
+	s := fmt.Sprintf("%s%dd", sign, d/Day)
+
+	if rest := d % Day; rest != 0 {
+		r := rest.String()
+
+		if strings.HasSuffix(r, "m0s") {
+			r = strings.TrimSuffix(r, "0s")
+		}
+
+		if strings.HasSuffix(r, "h0m") {
+			r = strings.TrimSuffix(r, "0m")
+		}
+
+		s += r
+	}
+
+	return s
+}
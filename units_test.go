@@ -0,0 +1,104 @@
+package gofigure_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseByteSize() {
+	for _, s := range []string{"512MiB", "1.5GB", "4k", "1024", "10 kb", "1.5B", "12XB"} {
+		size, err := gofigure.ParseByteSize(s)
+
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println(uint64(size), size)
+		}
+	}
+
+	// Output:
+	// 536870912 512MiB
+	// 1500000000 1.5GB
+	// 4096 4KiB
+	// 1024 1KiB
+	// 10000 10kB
+	// invalid value: "1.5B" is not a whole number of bytes in range
+	// invalid byte size: "12XB"
+}
+
+func ExampleParseDuration() {
+	for _, s := range []string{"7d", "2w", "1d12h30m", "-1.5d", "90m", "1x"} {
+		d, err := gofigure.ParseDuration(s)
+
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println(d, gofigure.FormatDuration(d))
+		}
+	}
+
+	// Output:
+	// 168h0m0s 1w
+	// 336h0m0s 2w
+	// 36h30m0s 1d12h30m
+	// -36h0m0s -1d12h
+	// 1h30m0s 1h30m0s
+	// invalid value: time: unknown unit "x" in duration "1x"
+}
+
+func TestByteSize(t *testing.T) {
+	t.Run("Sizes are reported in human form", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			cache     gofigure.ByteSize
+			retention time.Duration
+		)
+
+		config := gofigure.NewConfiguration("")
+		group := config.Group("storage")
+		group.Add(gofigure.Optional("Cache Size", "cache-size", &cache,
+			64*gofigure.Mebibyte, gofigure.Flag, gofigure.ReportValue, "Cache size"))
+		group.Add(gofigure.Optional("Retention", "retention", &retention,
+			gofigure.Week, gofigure.Flag, gofigure.ReportValue, "Retention"))
+
+		assert.Contains(t, config.Usage(), "Cache size (default: 64MiB)")
+		assert.Contains(t, config.Usage(), "Retention (default: 1w)")
+
+		assert.NoError(t, config.ParseUsing([]string{"--cache-size", "1.5GB",
+			"--retention", "30d"}))
+		assert.Equal(t, 1500*gofigure.Megabyte, cache)
+		assert.Equal(t, 30*gofigure.Day, retention)
+		assert.Equal(t, map[string]any{"Cache Size": "1.5GB", "Retention": "30d"},
+			config.Report()[0].Values)
+	})
+
+	t.Run("Sizes that are too large are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := gofigure.ParseByteSize("20000000PiB")
+		assert.ErrorIs(t, err, gofigure.ErrInvalidValue)
+
+		_, err = gofigure.ParseByteSize("20000000.5PiB")
+		assert.ErrorIs(t, err, gofigure.ErrInvalidValue)
+
+		_, err = gofigure.ParseByteSize("MiB")
+		assert.ErrorIs(t, err, gofigure.ErrInvalidSize)
+	})
+}
+
+func TestParseDuration(t *testing.T) {
+	t.Run("Invalid durations wrap the parse error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := gofigure.ParseDuration("1d2x")
+
+		assert.ErrorIs(t, err, gofigure.ErrInvalidValue)
+		assert.Contains(t, err.Error(), `duration "1d2x"`)
+		assert.Contains(t, err.Error(), `unknown unit "x"`)
+	})
+}
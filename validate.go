@@ -0,0 +1,292 @@
+package gofigure
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Validator checks the value of a Setting once parsing is complete. The
+// Description is shown in Usage. Choices are used for shell completion, and
+// Schema holds any JSON Schema keywords that describe the Validator.
+type Validator struct {
+	Description string
+	Choices     []string
+	Schema      map[string]any
+
+	check     func(value any) error
+	directory bool
+}
+
+// Ordered types that can be compared using Between, Min, and Max.
+type Ordered interface {
+	~float32 | ~float64 | ~string |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// validationError is returned by the built-in Validators.
+type validationError string
+
+// ErrFailedValidation is returned if a Setting fails validation.
+var ErrFailedValidation = errors.New("failed validation")
+
+// Check adds Validators to the Setting, returning the Setting. Validators are
+// run in order once all sources have been applied.
+func (s *Setting) Check(validators ...Validator) *Setting {
+	s.Validators = append(s.Validators, validators...)
+
+	return s
+}
+
+// Between returns a Validator that requires a value to be between lower and
+// upper, inclusive. As with Min, Max, and OneOf, numeric values are converted
+// to the type of the bounds, and values of other types fail with
+// ErrInvalidType.
+func Between[T Ordered](lower, upper T) Validator {
+	v := Validator{
+		Description: fmt.Sprintf("between %v and %v", lower, upper),
+		check: func(value any) error {
+			t, err := convert[T](value)
+
+			if errors.Is(err, ErrInvalidType) {
+				return err
+			} else if err != nil || t < lower || t > upper {
+				return validationError(fmt.Sprintf("must be between %v and %v", lower, upper))
+			}
+
+			return nil
+		},
+	}
+
+	if numeric(lower) {
+		v.Schema = map[string]any{"minimum": lower, "maximum": upper}
+	}
+
+	return v
+}
+
+// Min returns a Validator that requires a value to be at least lower.
+func Min[T Ordered](lower T) Validator {
+	v := Validator{
+		Description: fmt.Sprintf("at least %v", lower),
+		check: func(value any) error {
+			t, err := convert[T](value)
+
+			if errors.Is(err, ErrInvalidType) {
+				return err
+			} else if err != nil || t < lower {
+				return validationError(fmt.Sprintf("must be at least %v", lower))
+			}
+
+			return nil
+		},
+	}
+
+	if numeric(lower) {
+		v.Schema = map[string]any{"minimum": lower}
+	}
+
+	return v
+}
+
+// Max returns a Validator that requires a value to be at most upper.
+func Max[T Ordered](upper T) Validator {
+	v := Validator{
+		Description: fmt.Sprintf("at most %v", upper),
+		check: func(value any) error {
+			t, err := convert[T](value)
+
+			if errors.Is(err, ErrInvalidType) {
+				return err
+			} else if err != nil || t > upper {
+				return validationError(fmt.Sprintf("must be at most %v", upper))
+			}
+
+			return nil
+		},
+	}
+
+	if numeric(upper) {
+		v.Schema = map[string]any{"maximum": upper}
+	}
+
+	return v
+}
+
+// Matches returns a Validator that requires the string form of a value to
+// match the regular expression. Matches will panic if the expression is
+// invalid.
+func Matches(expression string) Validator {
+	re := regexp.MustCompile(expression)
+
+	return Validator{
+		Description: fmt.Sprintf("matching %s", expression),
+		Schema:      map[string]any{"pattern": expression},
+		check: func(value any) error {
+			if !re.MatchString(plain(value)) {
+				return validationError(fmt.Sprintf("must match %s", expression))
+			}
+
+			return nil
+		},
+	}
+}
+
+// OneOf returns a Validator that requires a value to be one of the given
+// choices.
+func OneOf[T comparable](choices ...T) Validator {
+	names := make([]string, len(choices))
+	enum := make([]any, len(choices))
+
+	for i, choice := range choices {
+		names[i] = fmt.Sprint(choice)
+		enum[i] = choice
+	}
+
+	return Validator{
+		Description: fmt.Sprintf("one of: %s", strings.Join(names, ", ")),
+		Choices:     names,
+		Schema:      map[string]any{"enum": enum},
+		check: func(value any) error {
+			t, err := convert[T](value)
+
+			if errors.Is(err, ErrInvalidType) {
+				return err
+			}
+
+			for _, choice := range choices {
+				if err == nil && t == choice {
+					return nil
+				}
+			}
+
+			return validationError(fmt.Sprintf("must be one of: %s",
+				strings.Join(names, ", ")))
+		},
+	}
+}
+
+// NotEmpty returns a Validator that requires a value to be set to something
+// other than the zero value for its type.
+func NotEmpty() Validator {
+	return Validator{
+		Description: "non-empty",
+		Schema:      map[string]any{"minLength": 1},
+		check: func(value any) error {
+			if value == nil || reflect.ValueOf(value).IsZero() {
+				return validationError("must not be empty")
+			}
+
+			return nil
+		},
+	}
+}
+
+// Custom returns a Validator that uses the given function to check a value.
+// Any error returned by the function will be reported to the user.
+func Custom[T any](description string, f func(T) error) Validator {
+	return Validator{
+		Description: description,
+		check: func(value any) error {
+			t, ok := value.(T)
+
+			if !ok {
+				return fmt.Errorf("%w: expected %T, got %T", ErrInvalidType, t, value)
+			}
+
+			return f(t)
+		},
+	}
+}
+
+// convert the value to type T. Numbers are converted using Convert, which
+// returns an error wrapping ErrInvalidValue if the value is out of range for T,
+// and strings are converted to named string types. An error wrapping
+// ErrInvalidType is returned for any other type.
+func convert[T any](value any) (T, error) {
+	var zero T
+
+	if t, ok := value.(T); ok {
+		return t, nil
+	}
+
+	converted, err := Convert(value, zero)
+
+	if err != nil {
+		return zero, err
+	} else if t, ok := converted.(T); ok {
+		return t, nil
+	}
+
+	v, target := reflect.ValueOf(value), reflect.TypeOf(zero)
+
+	if value != nil && target != nil && v.Kind() == reflect.String &&
+		target.Kind() == reflect.String {
+		t, _ := v.Convert(target).Interface().(T)
+
+		return t, nil
+	}
+
+	return zero, fmt.Errorf("%w: expected %T, got %T", ErrInvalidType, zero, value)
+}
+
+// Validate the value against the Validator.
+func (v Validator) Validate(value any) error {
+	if v.check == nil {
+		return nil
+	}
+
+	return v.check(value)
+}
+
+// validate the Setting using its Validators, returning a ConfigError naming
+// the Setting's Parameters if any Validator fails. The value is only included
+// in the error if the Setting's Mask would display it. Settings that have not
+// been set are not validated.
+func (s Setting) validate(prefix string) error {
+	if s.Value.Source == None {
+		return nil
+	}
+
+	for _, validator := range s.Validators {
+		err := validator.Validate(Dereference(s.Value.Ptr))
+
+		if err == nil {
+			continue
+		}
+
+		cause := fmt.Errorf("%w (%s)", ErrInvalidValue, err)
+
+		if value, ok := s.Display(); ok && value != Set && value != NotSet {
+			cause = fmt.Errorf("%w '%s' (%s)", ErrInvalidValue, value, err)
+		}
+
+		return NewConfigError(cause, fmt.Errorf("%w: %s %s: %w",
+			ErrFailedValidation, s.Value.Name, s.Parameters.Format(prefix), err),
+			s.Parameters...)
+	}
+
+	return nil
+}
+
+// numeric returns true if the value is a number that can be used in a JSON
+// Schema. Durations are not numeric as they are given as strings.
+func numeric(value any) bool {
+	if _, ok := value.(time.Duration); ok {
+		return false
+	}
+
+	return reflect.ValueOf(value).Kind() != reflect.String
+}
+
+func (v validationError) Error() string {
+	return string(v)
+}
+
+func (v validationError) Is(target error) bool {
+	return target == ErrFailedValidation
+}
@@ -0,0 +1,239 @@
+package gofigure_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/domdavis/gofigure"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSetting_Check() {
+	setup := func() *gofigure.Configuration {
+		var (
+			port int
+			mode string
+			name string
+		)
+
+		config := gofigure.NewConfiguration("EXAMPLE")
+		group := config.Group("settings")
+
+		group.Add(gofigure.Optional("Port", "port", &port, 8080, gofigure.Flag,
+			gofigure.ReportValue, "Port to listen on").Check(
+			gofigure.Between(1, 65535)))
+		group.Add(gofigure.Optional("Mode", "mode", &mode, "fast", gofigure.Flag,
+			gofigure.ReportValue, "Mode of operation").Check(
+			gofigure.OneOf("fast", "safe")))
+		group.Add(gofigure.Required("Name", "name", &name, gofigure.Flag,
+			gofigure.ReportValue, "Application name").Check(
+			gofigure.NotEmpty(), gofigure.Matches("^[a-z]+$")))
+
+		return config
+	}
+
+	fmt.Print(setup().Usage())
+
+	for _, args := range [][]string{
+		{"--port", "70000", "--name", "example"},
+		{"--mode", "slow", "--name", "example"},
+		{"--name", "Example"},
+	} {
+		config := setup()
+		err := config.ParseUsing(args)
+
+		fmt.Println(config.Format(err))
+	}
+
+	// Output:
+	// usage:
+	//   Port [--port]
+	//     Port to listen on (default: 8080; between 1 and 65535)
+	//
+	//   Mode [--mode]
+	//     Mode of operation (default: fast; one of: fast, safe)
+	//
+	//   Name [--name]
+	//     Application name (required; non-empty; matching ^[a-z]+$)
+	//
+	// invalid value '70000' (must be between 1 and 65535): [--port]
+	// invalid value 'slow' (must be one of: fast, safe): [--mode]
+	// invalid value 'Example' (must match ^[a-z]+$): [--name]
+}
+
+func TestValidator_Validate(t *testing.T) {
+	t.Run("Validators check values", func(t *testing.T) {
+		t.Parallel()
+
+		errEven := errors.New("must be even")
+		even := gofigure.Custom("even", func(i int) error {
+			if i%2 != 0 {
+				return errEven
+			}
+
+			return nil
+		})
+
+		for _, data := range []struct {
+			Validator gofigure.Validator
+			Valid     []any
+			Invalid   []any
+		}{
+			{
+				Validator: gofigure.Between(1, 10),
+				Valid:     []any{1, 5, 10},
+				Invalid:   []any{0, 11, "5"},
+			},
+			{
+				Validator: gofigure.Min(time.Second),
+				Valid:     []any{time.Second, time.Minute},
+				Invalid:   []any{time.Millisecond},
+			},
+			{
+				Validator: gofigure.Max(1.5),
+				Valid:     []any{1.5, -1.0},
+				Invalid:   []any{1.6},
+			},
+			{
+				Validator: gofigure.OneOf("a", "b"),
+				Valid:     []any{"a", "b"},
+				Invalid:   []any{"c", 1},
+			},
+			{
+				Validator: gofigure.NotEmpty(),
+				Valid:     []any{"a", 1, true},
+				Invalid:   []any{"", 0, false, nil},
+			},
+			{
+				Validator: gofigure.Matches("^a+$"),
+				Valid:     []any{"a", "aa"},
+				Invalid:   []any{"b", ""},
+			},
+			{
+				Validator: even,
+				Valid:     []any{0, 2},
+				Invalid:   []any{1, "2"},
+			},
+			{
+				Validator: gofigure.Validator{},
+				Valid:     []any{1, "a", nil},
+			},
+		} {
+			for _, value := range data.Valid {
+				assert.NoError(t, data.Validator.Validate(value),
+					"%s: %v", data.Validator.Description, value)
+			}
+
+			for _, value := range data.Invalid {
+				assert.Error(t, data.Validator.Validate(value),
+					"%s: %v", data.Validator.Description, value)
+			}
+		}
+	})
+
+	t.Run("Built in validators fail validation", func(t *testing.T) {
+		t.Parallel()
+
+		err := gofigure.NotEmpty().Validate("")
+
+		assert.ErrorIs(t, err, gofigure.ErrFailedValidation)
+	})
+}
+
+func TestSetting_Check(t *testing.T) {
+	t.Run("Masked values are not reported", func(t *testing.T) {
+		t.Parallel()
+
+		var password string
+
+		config := gofigure.NewConfiguration("")
+		config.Group("test").Add(gofigure.Required("Password", "password",
+			&password, gofigure.Flag, gofigure.MaskValue, "password").Check(
+			gofigure.Custom("strong", func(s string) error {
+				if len(s) < 8 {
+					return fmt.Errorf("%w: too short", gofigure.ErrFailedValidation)
+				}
+
+				return nil
+			})))
+
+		err := config.ParseUsing([]string{"--password", "secret"})
+
+		assert.ErrorIs(t, err, gofigure.ErrFailedValidation)
+		assert.Equal(t, "invalid value (failed validation: too short): [--password]",
+			config.Format(err))
+		assert.NotContains(t, err.Error(), "secret")
+	})
+
+	t.Run("Choices are used for completion", func(t *testing.T) {
+		t.Parallel()
+
+		var mode string
+
+		config := gofigure.NewConfiguration("")
+		config.Group("test").Add(gofigure.Optional("Mode", "mode", &mode,
+			"fast", gofigure.Flag, gofigure.ReportValue, "mode").Check(
+			gofigure.OneOf("fast", "safe")))
+
+		assert.Equal(t, []string{"fast", "safe"},
+			config.Complete([]string{"--mode", ""}))
+	})
+
+	t.Run("Constraints are included in the schema", func(t *testing.T) {
+		t.Parallel()
+
+		var port int
+
+		config := gofigure.NewConfiguration("")
+		config.Group("test").Add(gofigure.Optional("Port", "port", &port,
+			80, gofigure.Key, gofigure.ReportValue, "port").Check(
+			gofigure.Between(1, 1024), gofigure.OneOf(80, 443)))
+
+		schema, err := config.Schema()
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(schema), `"maximum": 1024`)
+		assert.Contains(t, string(schema), `"minimum": 1`)
+		assert.True(t, strings.Contains(string(schema), `"enum": [
+        80,
+        443
+      ]`))
+	})
+
+	t.Run("Values are converted to the type of the validator", func(t *testing.T) {
+		t.Parallel()
+
+		type mode string
+
+		var port uint16
+
+		config := gofigure.NewConfiguration("")
+		config.Group("test").Add(gofigure.Required("Port", "port", &port,
+			gofigure.Flag, gofigure.ReportValue, "port").Check(gofigure.Between(1, 65535),
+			gofigure.Min(1), gofigure.Max(65535), gofigure.OneOf(80, 8080)))
+
+		assert.NoError(t, config.ParseUsing([]string{"--port", "8080"}))
+		assert.NoError(t, gofigure.OneOf("fast", "safe").Validate(mode("safe")))
+		assert.NoError(t, gofigure.Max(10).Validate(uint64(10)))
+		assert.ErrorIs(t, gofigure.Max(10).Validate(uint64(1<<63)), gofigure.ErrFailedValidation)
+		assert.ErrorIs(t, gofigure.Between(1, 10).Validate("5"), gofigure.ErrInvalidType)
+	})
+
+	t.Run("Config files are validated when checked", func(t *testing.T) {
+		t.Parallel()
+
+		var name string
+
+		config := gofigure.NewConfiguration("")
+		config.Group("test").Add(gofigure.Optional("Name", "name", &name,
+			"default", gofigure.Key, gofigure.ReportValue, "name").Check(
+			gofigure.OneOf("default")))
+
+		err := config.CheckConfig("testdata/config.json")
+
+		assert.ErrorIs(t, err, gofigure.ErrFailedValidation)
+	})
+}
@@ -1,24 +1,34 @@
 package gofigure
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"net/netip"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // A Value is used to hold a configured value. The Value must be a pointer to
 // the variable being set, and must satisfy Type. Once set the Value will
-// contain the Source that provided the value.
+// contain the Source that provided the value, and the Provenance of the value
+// (e.g. the flag, environment variable, or file it was read from). The Layout
+// is used to parse and report time.Time and Date values.
 type Value struct {
 	Name        string
 	Description string
 	Ptr         any
+	Layout      string
 
-	Source Source
+	Source     Source
+	Provenance string
 
 	base any
+	enum *enum
 }
 
 // External types hold a path to an external configuration file.
@@ -28,7 +38,9 @@ type External string
 type Type interface {
 	~bool | ~float32 | ~float64 | ~string |
 		~int | ~int8 | ~int16 | ~int32 | ~int64 |
-		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		time.Time | Date | *time.Location |
+		*url.URL | netip.Addr | netip.Prefix
 }
 
 // Value validation errors.
@@ -40,7 +52,8 @@ var (
 )
 
 // NewValue returns a new, valid value. An empty name, description, or an
-// invalid ptr will result in a panic.
+// invalid ptr will result in a panic. A leading ~ in a Path value is expanded
+// (see ExpandPath).
 func NewValue[T Type](name string, ptr *T, value T, source Source, description string) *Value {
 	s := &Value{Name: name, Description: description, Ptr: ptr, Source: source}
 
@@ -48,6 +61,11 @@ func NewValue[T Type](name string, ptr *T, value T, source Source, description s
 		panic(err)
 	}
 
+	if path, ok := any(value).(Path); ok {
+		expanded, _ := ExpandPath(string(path))
+		value, _ = any(expanded).(T)
+	}
+
 	if source.Contains(Default) {
 		*ptr = value
 		s.base = value
@@ -68,10 +86,16 @@ func (v *Value) Validate() error {
 		return fmt.Errorf("%w: (Value %s)", ErrNilPointer, v.Name)
 	}
 
+	if v.enum != nil && reflect.TypeOf(v.Ptr).Kind() == reflect.Ptr {
+		return nil
+	}
+
 	switch v.Ptr.(type) {
-	case *bool, *float32, *float64, *string, *time.Duration, *External,
+	case *bool, *float32, *float64, *string, *time.Duration, *External, *Secret, *ByteSize,
 		*int, *int8, *int16, *int32, *int64,
-		*uint, *uint8, *uint16, *uint32, *uint64:
+		*uint, *uint8, *uint16, *uint32, *uint64,
+		*time.Time, *Date, **time.Location,
+		*HostPort, *Path, **url.URL, *netip.Addr, *netip.Prefix:
 	default:
 		return fmt.Errorf("%w for Value %s: %T", ErrInvalidType, v.Name, v.Ptr)
 	}
@@ -79,6 +103,12 @@ func (v *Value) Validate() error {
 	return nil
 }
 
+// explicit returns true if the Value has been set by a Source other than a
+// default value.
+func (v *Value) explicit() bool {
+	return !v.Source.Contains(None | Default)
+}
+
 // Assign a value to the Value.Ptr, returning an error if the assignment
 // cannot be made.
 //
@@ -90,6 +120,10 @@ func (v *Value) Assign(value any, source Source) error {
 		return fmt.Errorf("cannot assign %v to invalid setting: %w", value, err)
 	}
 
+	if v.enum != nil {
+		return v.assignEnum(value, source)
+	}
+
 	switch target := v.Ptr.(type) {
 	case *bool:
 		err = Assign(target, value)
@@ -121,6 +155,28 @@ func (v *Value) Assign(value any, source Source) error {
 		err = Assign(target, value)
 	case *time.Duration:
 		err = Assign(target, value)
+	case *ByteSize:
+		err = Assign(target, value)
+	case *time.Time:
+		if value, err = v.timestamp(value); err == nil {
+			err = Assign(target, value)
+		}
+	case *Date:
+		if value, err = v.timestamp(value); err == nil {
+			err = Assign(target, value)
+		}
+	case **time.Location:
+		err = Assign(target, value)
+	case *HostPort:
+		err = Assign(target, v.hostPort(value))
+	case *Path:
+		err = Assign(target, value)
+	case **url.URL:
+		err = Assign(target, value)
+	case *netip.Addr:
+		err = Assign(target, value)
+	case *netip.Prefix:
+		err = Assign(target, value)
 	case *External:
 		if e, ok := value.(External); ok {
 			err = Assign(target, e)
@@ -129,6 +185,14 @@ func (v *Value) Assign(value any, source Source) error {
 		} else {
 			err = Assign(target, External(s))
 		}
+	case *Secret:
+		if secret, ok := value.(Secret); ok {
+			err = Assign(target, secret)
+		} else if s, ok := value.(string); !ok {
+			err = ErrInvalidType
+		} else {
+			err = Assign(target, Secret(s))
+		}
 	}
 
 	if err != nil {
@@ -141,7 +205,8 @@ func (v *Value) Assign(value any, source Source) error {
 }
 
 // Assign the value to the target, returning an error if assignment fails.
-// Assign will attempt to coerce string values to the correct type.
+// Assign will attempt to coerce string values to the correct type, and will
+// convert numeric values to the correct type if they are in range.
 func Assign[T Type](target *T, value any) (err error) {
 	var (
 		ok     bool
@@ -154,7 +219,13 @@ func Assign[T Type](target *T, value any) (err error) {
 		return fmt.Errorf("assignment error: %w", err)
 	}
 
-	*target, ok = Cast(value, typeOf).(T)
+	value, err = Convert(value, typeOf)
+
+	if err != nil {
+		return fmt.Errorf("assignment error: %w", err)
+	}
+
+	*target, ok = value.(T)
 
 	if !ok {
 		return ErrInvalidType
@@ -163,12 +234,22 @@ func Assign[T Type](target *T, value any) (err error) {
 	return nil
 }
 
-// Coerce will coerce string values to the correct type. All other value types
-// are simply returned as is. If the string value cannot be coerced to the type
-// an error is returned.
+// Coerce will coerce string values, and json.Number values, to the correct
+// type. All other value types are simply returned as is. If the string value
+// cannot be coerced to the type an error is returned. Integers can be given in
+// decimal, or in hexadecimal, octal, or binary using a 0x, 0o, or 0b prefix,
+// and can use _ as a separator. Durations can use days and weeks (see
+// ParseDuration), and ByteSize values can use units (see ParseByteSize). Times
+// use RFC 3339, dates use DateLayout, and locations use IANA names (e.g.
+// Europe/London). Paths have a leading ~ expanded (see ExpandPath). Values that
+// are out of range for the type return an error wrapping ErrInvalidValue.
 //
-//nolint:cyclop,funlen // Case switch for all available types.
+//nolint:cyclop // Case switch for all available types.
 func Coerce(value, typeOf any) (r any, err error) {
+	if n, ok := value.(json.Number); ok {
+		return coerceNumber(n, typeOf)
+	}
+
 	s, ok := value.(string)
 
 	if !ok {
@@ -177,60 +258,177 @@ func Coerce(value, typeOf any) (r any, err error) {
 
 	t := reflect.TypeOf(typeOf)
 
+	if r, ok, err := parse(s, t); ok {
+		return r, coerceError(s, t, err)
+	}
+
 	switch {
-	case t == reflect.TypeOf(time.Duration(1)):
-		r, err = time.ParseDuration(s)
 	case t.Kind() == reflect.Bool:
 		r, err = strconv.ParseBool(s)
-	case t.Kind() == reflect.Int:
-		v, e := strconv.ParseInt(s, 10, 64)
-		r, err = int(v), e
-	case t.Kind() == reflect.Int8:
-		v, e := strconv.ParseInt(s, 10, 64)
-		r, err = int8(v), e
-	case t.Kind() == reflect.Int16:
-		v, e := strconv.ParseInt(s, 10, 64)
-		r, err = int16(v), e
-	case t.Kind() == reflect.Int32:
-		v, e := strconv.ParseInt(s, 10, 64)
-		r, err = int32(v), e
-	case t.Kind() == reflect.Int64:
-		r, err = strconv.ParseInt(s, 10, 64)
-	case t.Kind() == reflect.Uint:
-		v, e := strconv.ParseUint(s, 10, 64)
-		r, err = uint(v), e
-	case t.Kind() == reflect.Uint8:
-		v, e := strconv.ParseInt(s, 10, 64)
-		r, err = uint8(v), e
-	case t.Kind() == reflect.Uint16:
-		v, e := strconv.ParseInt(s, 10, 64)
-		r, err = uint16(v), e
-	case t.Kind() == reflect.Uint32:
-		v, e := strconv.ParseInt(s, 10, 64)
-		r, err = uint32(v), e
-	case t.Kind() == reflect.Uint64:
-		r, err = strconv.ParseUint(s, 10, 64)
-	case t.Kind() == reflect.Float32:
-		v, e := strconv.ParseFloat(s, 32)
-		r, err = float32(v), e
-	case t.Kind() == reflect.Float64:
-		r, err = strconv.ParseFloat(s, 64)
+	case signed(t.Kind()):
+		var i int64
+
+		i, err = strconv.ParseInt(integer(s), 0, t.Bits())
+		r = reflect.ValueOf(i).Convert(t).Interface()
+	case unsigned(t.Kind()):
+		var u uint64
+
+		u, err = strconv.ParseUint(strings.TrimPrefix(integer(s), "+"), 0, t.Bits())
+		r = reflect.ValueOf(u).Convert(t).Interface()
+
+		if i, e := strconv.ParseInt(integer(s), 0, 64); err != nil && e == nil && i < 0 {
+			err = strconv.ErrRange
+		}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		var f float64
+
+		f, err = strconv.ParseFloat(s, t.Bits())
+		r = reflect.ValueOf(f).Convert(t).Interface()
 	case t.Kind() == reflect.String:
-		r = s
+		r = reflect.ValueOf(s).Convert(t).Interface()
 	default:
 		r = s
 		err = fmt.Errorf("%w: %v", ErrInvalidType, t)
 	}
 
+	return r, coerceError(s, t, err)
+}
+
+// coerceError wraps an error from coercing the string to the type. Range
+// errors are reported as ErrInvalidValue.
+func coerceError(s string, t reflect.Type, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		err = fmt.Errorf("%w: out of range for %v", ErrInvalidValue, t)
+	}
+
 	if err != nil {
 		err = fmt.Errorf("cannot coerce %q: %w", s, err)
 	}
 
+	return err
+}
+
+// parse the string as one of the types with its own format, returning false if
+// the type does not have its own format.
+//
+//nolint:cyclop // Case switch for types with their own format.
+func parse(s string, t reflect.Type) (r any, ok bool, err error) {
+	switch t {
+	case reflect.TypeOf(time.Duration(1)):
+		r, err = ParseDuration(s)
+	case reflect.TypeOf(ByteSize(0)):
+		r, err = ParseByteSize(s)
+	case reflect.TypeOf(time.Time{}):
+		r, err = time.Parse(time.RFC3339, s)
+	case reflect.TypeOf(Date{}):
+		r, err = ParseDate(s)
+	case reflect.TypeOf((*time.Location)(nil)):
+		r, err = time.LoadLocation(s)
+	case reflect.TypeOf(HostPort("")):
+		r, err = ParseHostPort(s)
+	case reflect.TypeOf(Path("")):
+		r, err = ExpandPath(s)
+	case reflect.TypeOf((*url.URL)(nil)):
+		r, err = url.Parse(s)
+	case reflect.TypeOf(netip.Addr{}):
+		r, err = netip.ParseAddr(s)
+	case reflect.TypeOf(netip.Prefix{}):
+		r, err = netip.ParsePrefix(s)
+	default:
+		return nil, false, nil
+	}
+
+	return r, true, err
+}
+
+// coerceNumber coerces a JSON number to the correct type. Integers are parsed
+// exactly, falling back to a range checked conversion from float64 for values
+// using a fraction or exponent (e.g. 1e3). Numbers for non-numeric types are
+// returned as float64.
+func coerceNumber(n json.Number, typeOf any) (any, error) {
+	t := reflect.TypeOf(typeOf)
+	kind := t.Kind()
+
+	if !number(kind) || t == reflect.TypeOf(time.Duration(0)) {
+		return n.Float64()
+	} else if !signed(kind) && !unsigned(kind) {
+		return Coerce(string(n), typeOf)
+	}
+
+	r, err := Coerce(string(n), typeOf)
+
+	if errors.Is(err, strconv.ErrSyntax) {
+		var f float64
+
+		if f, err = n.Float64(); err == nil {
+			return Convert(f, typeOf)
+		}
+	}
+
 	return r, err
 }
 
+// Convert a numeric value to the numeric type of typeOf. An error wrapping
+// ErrInvalidValue is returned if the value is out of range for the type, or if
+// a value with a fractional part is converted to an integer. Non-numeric
+// values, and values for non-numeric types, are returned as is. Numbers are
+// never converted to durations.
+//
+//nolint:cyclop,funlen // Checks for all numeric kinds.
+func Convert(value, typeOf any) (any, error) {
+	v := reflect.ValueOf(value)
+	t := reflect.TypeOf(typeOf)
+
+	switch {
+	case value == nil || typeOf == nil || v.Type() == t:
+		return value, nil
+	case !number(v.Kind()) || !number(t.Kind()) || t == reflect.TypeOf(time.Duration(1)):
+		return value, nil
+	}
+
+	target := reflect.New(t).Elem()
+	invalid := fmt.Errorf("%w: %v is out of range for %v", ErrInvalidValue, value, t)
+
+	switch k := v.Kind(); {
+	case k == reflect.Float32 || k == reflect.Float64:
+		f := v.Float()
+
+		switch {
+		case (signed(t.Kind()) || unsigned(t.Kind())) && f != math.Trunc(f):
+			return value, fmt.Errorf("%w: %v is not a whole number", ErrInvalidValue, value)
+		case signed(t.Kind()) && (f < -math.Ldexp(1, t.Bits()-1) || f >= math.Ldexp(1, t.Bits()-1)):
+			return value, invalid
+		case unsigned(t.Kind()) && (f < 0 || f >= math.Ldexp(1, t.Bits())):
+			return value, invalid
+		case t.Kind() == reflect.Float32 && target.OverflowFloat(f):
+			return value, invalid
+		}
+	case signed(k):
+		i := v.Int()
+
+		switch {
+		case signed(t.Kind()) && target.OverflowInt(i):
+			return value, invalid
+		case unsigned(t.Kind()) && (i < 0 || target.OverflowUint(uint64(i))):
+			return value, invalid
+		}
+	default:
+		u := v.Uint()
+
+		switch {
+		case signed(t.Kind()) && (u > math.MaxInt64 || target.OverflowInt(int64(u))):
+			return value, invalid
+		case unsigned(t.Kind()) && target.OverflowUint(u):
+			return value, invalid
+		}
+	}
+
+	return v.Convert(t).Interface(), nil
+}
+
 // Cast a float64 value to an integer value. If the value isn't a float64, or
-// the type isn't an integer then Cast will simply return the value.
+// the type isn't an integer then Cast will simply return the value. Cast
+// truncates values, use Convert to check the value is in range.
 //
 //nolint:cyclop // Case switch for all supported types.
 func Cast(value, typeOf any) any {
@@ -268,6 +466,39 @@ func Cast(value, typeOf any) any {
 	}
 }
 
+// integer prepares a string for parsing as an integer using base 0. A leading
+// zero without a base prefix is treated as decimal, rather than octal.
+func integer(s string) string {
+	var sign string
+
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+
+	if len(s) > 1 && s[0] == '0' && !strings.ContainsAny(s[1:2], "xXoObB_") {
+		if s = strings.TrimLeft(s, "0"); s == "" || s[0] == '_' {
+			s = "0" + s
+		}
+	}
+
+	return sign + s
+}
+
+// signed returns true if the kind is a signed integer.
+func signed(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+// unsigned returns true if the kind is an unsigned integer.
+func unsigned(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uint64
+}
+
+// number returns true if the kind is an integer or floating point number.
+func number(kind reflect.Kind) bool {
+	return signed(kind) || unsigned(kind) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
 // Dereference a value. If the value isn't a pointer then it is returned as is.
 func Dereference(in any) any {
 	if in == nil || reflect.TypeOf(in).Kind() != reflect.Ptr {
@@ -2,6 +2,7 @@ package gofigure_test
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"testing"
 	"time"
@@ -78,6 +79,25 @@ func ExampleCast() {
 	// string: 1.0
 }
 
+func ExampleConvert() {
+	fmt.Println(gofigure.Convert(8.0, int8(0)))
+	fmt.Println(gofigure.Convert(300.0, uint8(0)))
+	fmt.Println(gofigure.Convert(1.9, 0))
+	fmt.Println(gofigure.Convert(-1, uint(0)))
+	fmt.Println(gofigure.Convert(uint64(math.MaxUint64), int64(0)))
+	fmt.Println(gofigure.Convert(1e40, float32(0)))
+	fmt.Println(gofigure.Convert("1", 0))
+
+	// Output:
+	// 8 <nil>
+	// 300 invalid value: 300 is out of range for uint8
+	// 1.9 invalid value: 1.9 is not a whole number
+	// -1 invalid value: -1 is out of range for uint
+	// 18446744073709551615 invalid value: 18446744073709551615 is out of range for int64
+	// 1e+40 invalid value: 1e+40 is out of range for float32
+	// 1 <nil>
+}
+
 func ExampleDereference() {
 	i := 1
 
@@ -129,6 +149,55 @@ func TestValue_Validate(t *testing.T) {
 	})
 }
 
+func TestCoerce(t *testing.T) {
+	t.Run("Integers can use base prefixes and separators", func(t *testing.T) {
+		t.Parallel()
+
+		for in, expected := range map[string]any{
+			"0x1F":      31,
+			"0o17":      15,
+			"0b101":     5,
+			"1_000_000": 1000000,
+			"010":       10,
+			"-007":      -7,
+			"0":         0,
+		} {
+			actual, err := gofigure.Coerce(in, 0)
+
+			assert.NoError(t, err, in)
+			assert.Equal(t, expected, actual, in)
+		}
+	})
+
+	t.Run("Out of range values are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		for in, typeOf := range map[string]any{
+			"300":    uint8(0),
+			"-1":     uint(0),
+			"128":    int8(0),
+			"0x1_00": uint8(0),
+			"1e40":   float32(0),
+		} {
+			_, err := gofigure.Coerce(in, typeOf)
+
+			assert.ErrorIs(t, err, gofigure.ErrInvalidValue, in)
+		}
+	})
+
+	t.Run("Unsigned values can have a leading plus", func(t *testing.T) {
+		t.Parallel()
+
+		actual, err := gofigure.Coerce("+5", uint8(0))
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(5), actual)
+
+		_, err = gofigure.Coerce("+x", uint8(0))
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, gofigure.ErrInvalidValue)
+	})
+}
+
 func TestValue_Assign(t *testing.T) {
 	for _, target := range []any{
 		new(bool), new(time.Duration), new(gofigure.External),
@@ -157,6 +226,19 @@ func TestValue_Assign(t *testing.T) {
 		}(target)
 	}
 
+	t.Run("Out of range JSON numbers are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		var port uint16
+
+		value := gofigure.NewValue("port", &port, 0, gofigure.Default, "port")
+
+		assert.ErrorIs(t, value.Assign(70000.0, gofigure.Key), gofigure.ErrInvalidValue)
+		assert.ErrorIs(t, value.Assign(80.5, gofigure.Key), gofigure.ErrInvalidValue)
+		assert.NoError(t, value.Assign(8080.0, gofigure.Key))
+		assert.Equal(t, uint16(8080), port)
+	})
+
 	t.Run("External accepts External types", func(t *testing.T) {
 		t.Parallel()
 